@@ -1,11 +1,12 @@
 package telego
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"os"
 
 	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/recorder"
 )
 
 /*
@@ -336,7 +337,7 @@ func (bot *AdvancedBot) ACreateAlbum(replyTo, messageThreadId int, allowSendingW
 	if keyboard != nil {
 		replyMarkup = keyboard.toMarkUp()
 	}
-	return &MediaGroup{replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, media: make([]objs.InputMedia, 0), files: make([]*os.File, 0), allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
+	return &MediaGroup{replyTo: replyTo, messageThreadId: messageThreadId, bot: bot.bot, media: make([]objs.InputMedia, 0), files: make([]*objs.InputFile, 0), allowSendingWihoutReply: allowSendingWihtoutReply, replyMarkup: replyMarkup}
 }
 
 /*
@@ -749,6 +750,16 @@ func (bot *AdvancedBot) AddMiddleware(md func(update *objs.Update, next func()))
 	bot.bot.apiInterface.GetUpdateParser().AddMiddleWare(md)
 }
 
+/*
+ReplayUpdates replays the updates recorded by a "recorder.Recorder" in the given file. The updates are passed through the middlewares and handlers of the bot just like the received updates.
+If "originalSpeed" is true, the time between the updates is kept as it was recorded.
+
+The bot should be running, so the updates that are not handled by the handlers can be received from the channels.
+*/
+func (bot *AdvancedBot) ReplayUpdates(ctx context.Context, path string, originalSpeed bool) error {
+	return recorder.ReplayFile(ctx, path, bot.bot.apiInterface.GetUpdateParser(), originalSpeed)
+}
+
 func (bot *AdvancedBot) getChannel(chatId, media string) *chan *objs.Update {
 	if bot.bot.channelsMap[chatId] == nil {
 		bot.bot.channelsMap[chatId] = make(map[string]*chan *objs.Update)
@@ -1,58 +1,146 @@
 package telego
 
 import (
+	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
+	"io"
+	"net/http"
 	"os"
+	"sync"
 
 	cfg "github.com/SakoDroid/telego/v2/configs"
 	errs "github.com/SakoDroid/telego/v2/errors"
 	logger "github.com/SakoDroid/telego/v2/logger"
 	objs "github.com/SakoDroid/telego/v2/objects"
+	upp "github.com/SakoDroid/telego/v2/parser"
 	tba "github.com/SakoDroid/telego/v2/tba"
 )
 
 type Bot struct {
 	botCfg                 *cfg.BotConfigs
-	apiInterface           *tba.BotAPIInterface
+	apiInterface           tba.API
 	channelsMap            map[string]map[string]*chan *objs.Update
 	interfaceUpdateChannel *chan *objs.Update
 	chatUpdateChannel      *chan *objs.ChatUpdate
 	prcRoutineChannel      *chan bool
 	ab                     *AdvancedBot
 	logger                 *logger.BotLogger
+	polls                  *pollStore
+	webhook                *tba.Webhook
+	runMx                  *sync.Mutex
+	stopCfgRoutine         *context.CancelFunc
+	ctx                    context.Context //The context set by "WithContext". Nil for the original bot.
 }
 
 /*Run starts the bot. If the bot has already been started it returns an error.*/
 func (bot *Bot) Run(autoPause bool) error {
+	return bot.RunCtx(context.Background(), autoPause)
+}
+
+/*
+RunCtx starts the bot just like "Run" but the update routine is bound to the given context. When the context is cancelled, the bot stops receiving updates.
+
+If "autoPause" is true, this method blocks until the context is cancelled.
+*/
+func (bot *Bot) RunCtx(ctx context.Context, autoPause bool) error {
 	logger.InitTheLogger(bot.botCfg)
-	if !bot.checkWebHook() {
-		bot.logger.GetRaw().Fatalln("Webhook check failed. See the logs for more info.")
+	certCreated := false
+	if bot.botCfg.Webhook {
+		var err error
+		certCreated, err = bot.webhook.LoadCertificate()
+		if err != nil {
+			return err
+		}
+		bot.webhook.OnCertRenewal = bot.setWebhook
+	}
+	if !bot.checkWebHook(certCreated) {
+		return errors.New("webhook check failed. See the logs for more info")
 	}
-	go bot.startChatUpdateRoutine()
-	go bot.startUpdateProcessing()
-	cfg.Dump(bot.botCfg)
-	go bot.botCfg.StartCfgUpdateRoutine()
 	var err error
 	if bot.botCfg.Webhook {
-		wh := tba.Webhook{
-			Logger: bot.logger,
+		if bot.botCfg.WebHookConfigs.ExternalServer {
+			bot.webhook.Open()
+		} else {
+			err = bot.webhook.StartWebHook(bot.botCfg, bot.apiInterface.GetUpdateParser())
+		}
+		if err == nil && ctx.Done() != nil {
+			go func() {
+				<-ctx.Done()
+				bot.webhook.Shutdown(context.Background())
+			}()
 		}
-		err = wh.StartWebHook(bot.botCfg, bot.apiInterface.GetUpdateParser())
 	} else {
-		err = bot.apiInterface.StartUpdateRoutine()
+		err = bot.apiInterface.StartUpdateRoutineCtx(ctx)
 	}
 	if err != nil {
 		return err
 	}
+	cfg.Dump(bot.botCfg)
+	bot.runMx.Lock()
+	if *bot.prcRoutineChannel == nil {
+		stop := make(chan bool)
+		*bot.prcRoutineChannel = stop
+		go bot.startChatUpdateRoutine(stop)
+		go bot.startUpdateProcessing(stop)
+		cfgCtx, cancel := context.WithCancel(context.Background())
+		*bot.stopCfgRoutine = cancel
+		go bot.botCfg.StartCfgUpdateRoutineCtx(cfgCtx)
+	}
+	bot.runMx.Unlock()
 	if autoPause {
-		ch := make(chan bool)
-		<-ch
+		<-ctx.Done()
 	}
 	return nil
 }
 
-func (bot *Bot) checkWebHook() bool {
+/*
+WebhookHandler returns the http handler which receives the updates when webhook is used. This handler can be mounted on an existing http server at any path,
+in this case "ExternalServer" field of the webhook configs should be true so the bot does not start it's own server. The handler should not be used before calling "Run".
+*/
+func (bot *Bot) WebhookHandler() http.Handler {
+	return bot.webhook
+}
+
+/*
+WithContext returns a copy of the bot which sends all of it's api requests with the given context. Every tool created by the returned bot (MediaSender, MessageEditor, ChatManager and etc.) uses the given context too.
+Cancelling the context aborts the pending requests and they return the context's error.
+
+The returned bot shares the configs, handlers and channels with the original bot and should only be used for calling the api methods. Use the original bot for running and stopping.
+*/
+func (bot *Bot) WithContext(ctx context.Context) *Bot {
+	out := *bot
+	out.ctx = ctx
+	out.apiInterface = bot.apiInterface.WithContext(ctx)
+	out.ab = &AdvancedBot{bot: &out}
+	return &out
+}
+
+/*
+ReplyInWebhook returns a copy of the bot whose first api call can be sent in the response of the webhook request of the given update, when "ReplyInResponse" field of the webhook configs is true.
+The call is only sent in the response if it's sent before the handlers of the update return, otherwise it's sent normally. Calls sent by other bots are never sent in the webhook response.
+Telegram does not return the result of these calls, so the methods which only return true (such as answerCallbackQuery or deleteMessage) return true and the other methods return a successful result
+with an empty "Result" field (for example the sent message is nil). Only use the returned bot for the calls whose result is not used :
+
+	bot.AddHandler("hi", func(u *objs.Update) {
+		bot.ReplyInWebhook(u).SendMessage(u.Message.Chat.Id, "hello", "", 0, false, false, nil)
+	}, "private")
+*/
+func (bot *Bot) ReplyInWebhook(update *objs.Update) *Bot {
+	return bot.WithContext(tba.WithWebhookReply(bot.context(), update))
+}
+
+/*Returns the context set by "WithContext", or the background context.*/
+func (bot *Bot) context() context.Context {
+	if bot.ctx == nil {
+		return context.Background()
+	}
+	return bot.ctx
+}
+
+/*Checks the webhook of the bot in the api server and sets or deletes it if necessary. If "uploadCert" is true, the webhook is set again so the new certificate is uploaded.*/
+func (bot *Bot) checkWebHook(uploadCert bool) bool {
 	wi, err := bot.apiInterface.GetWebhookInfo()
 	if err != nil {
 		bot.logger.GetRaw().Println(err)
@@ -68,7 +156,13 @@ func (bot *Bot) checkWebHook() bool {
 		}
 	} else {
 		if bot.botCfg.Webhook {
-			if wi.Result.URL != bot.botCfg.WebHookConfigs.URL {
+			if wi.Result.URL == bot.botCfg.WebHookConfigs.URL && uploadCert {
+				err2 := bot.setWebhook()
+				if err2 != nil {
+					bot.logger.GetRaw().Println("Unable to upload the new certificate.", err2)
+					return false
+				}
+			} else if wi.Result.URL != bot.botCfg.WebHookConfigs.URL {
 				bot.logger.GetRaw().Println("A webhook is already set in the API server to this url :", wi.Result.URL, ". Deleting the webhook ...")
 				err2 := bot.deleteWebhook()
 				if err2 != nil {
@@ -97,13 +191,9 @@ func (bot *Bot) checkWebHook() bool {
 func (bot *Bot) setWebhook() error {
 	bot.logger.GetRaw().Println("Setting webhook ...")
 	whcfg := bot.botCfg.WebHookConfigs
-	var fl *os.File
+	var fl *objs.InputFile
 	if whcfg.SelfSigned {
-		var err2 error
-		fl, err2 = os.Open(whcfg.CertFile)
-		if err2 != nil {
-			return err2
-		}
+		fl = objs.FileFromPath(whcfg.CertFile)
 	}
 	res, err3 := bot.apiInterface.SetWebhook(whcfg.URL, whcfg.IP, whcfg.MaxConnections, whcfg.AllowedUpdates, whcfg.DropPendingUpdates, fl)
 	if err3 != nil {
@@ -150,18 +240,52 @@ AddHandler adds a handler for a text message that matches the given regex patter
 "pattern" is a regex pattern.
 
 "chatType" must be "private","group","supergroup","channel" or "all". Any other value will cause the function to return an error.
+
+The handler is added with priority 0. If several handlers match a message, only one of them is executed : a handler is checked before the handlers whose regex matches it's pattern
+(so "hi guys" is checked before "hi" regardless of the order they are added), otherwise the handler which has been added first is executed. Use "AddHandlerWithPriority" to change the order.
 */
 func (bot *Bot) AddHandler(pattern string, handler func(*objs.Update), chatTypes ...string) error {
 	if len(chatTypes) == 0 {
 		return errors.New("please specify a chat type")
 	}
+	if err := checkChatTypes(chatTypes); err != nil {
+		return err
+	}
+	return bot.apiInterface.GetUpdateParser().AddHandler(pattern, handler, chatTypes...)
+
+}
+
+/*
+AddHandlerWithPriority works like "AddHandler" but handlers with higher priority are checked before the others (handlers with the same priority are ordered like "AddHandler").
+Returns the id of the handler which can be passed to "RemoveHandler".
+*/
+func (bot *Bot) AddHandlerWithPriority(pattern string, priority int, handler func(*objs.Update), chatTypes ...string) (int, error) {
+	if len(chatTypes) == 0 {
+		return 0, errors.New("please specify a chat type")
+	}
+	if err := checkChatTypes(chatTypes); err != nil {
+		return 0, err
+	}
+	return bot.apiInterface.GetUpdateParser().AddHandlerWithPriority(pattern, priority, handler, chatTypes...)
+}
+
+/*RemoveHandler removes the text handler with the given id. Returns false if no such handler exists.*/
+func (bot *Bot) RemoveHandler(id int) bool {
+	return bot.apiInterface.GetUpdateParser().RemoveHandler(id)
+}
+
+/*Handlers returns the id, pattern, chat types and priority of the registered text handlers in the order they are checked. It can be used for debugging the handlers.*/
+func (bot *Bot) Handlers() []upp.HandlerInfo {
+	return bot.apiInterface.GetUpdateParser().Handlers()
+}
+
+func checkChatTypes(chatTypes []string) error {
 	for _, val := range chatTypes {
 		if val != "private" && val != "group" && val != "supergroup" && val != "channel" && val != "all" {
 			return errors.New("unknown chat type : " + val)
 		}
 	}
-	return bot.apiInterface.GetUpdateParser().AddHandler(pattern, handler, chatTypes...)
-
+	return nil
 }
 
 /*
@@ -177,6 +301,32 @@ func (bot *Bot) GetMe() (*objs.Result[*objs.User], error) {
 	return bot.apiInterface.GetMe()
 }
 
+/*
+LogOut logs out the bot from the cloud bot api server. Call this method before running the bot on a local bot api server.
+
+--------------------
+
+Official telegarm doc :
+
+Use this method to log out from the cloud Bot API server before launching the bot locally. You must log out the bot before running it locally, otherwise there is no guarantee that the bot will receive updates. After a successful call, you can immediately log in on a local server, but will not be able to log in back to the cloud Bot API server for 10 minutes. Returns True on success. Requires no parameters.
+*/
+func (bot *Bot) LogOut() (*objs.Result[bool], error) {
+	return bot.apiInterface.LogOut()
+}
+
+/*
+Close closes the bot instance on the bot api server. Call this method before moving the bot from one local server to another.
+
+--------------------
+
+Official telegarm doc :
+
+Use this method to close the bot instance before moving it from one local server to another. You need to delete the webhook before calling this method to ensure that the bot isn't launched again after server restart. The method will return error 429 in the first 10 minutes after the bot is launched. Returns True on success. Requires no parameters.
+*/
+func (bot *Bot) Close() (*objs.Result[bool], error) {
+	return bot.apiInterface.Close()
+}
+
 // GetBotManager returns a bot manager, a tool for manging personal information of the bot such as name and description.
 func (bot *Bot) GetBotManager() *BotManager {
 	return &BotManager{bot: bot}
@@ -449,7 +599,7 @@ CreateAlbum returns a MediaGroup for grouping media messages.
 To ignore replyTo argument, pass 0.
 */
 func (bot *Bot) CreateAlbum(replyTo int) *MediaGroup {
-	return &MediaGroup{replyTo: replyTo, bot: bot, media: make([]objs.InputMedia, 0), files: make([]*os.File, 0)}
+	return &MediaGroup{replyTo: replyTo, bot: bot, media: make([]objs.InputMedia, 0), files: make([]*objs.InputFile, 0)}
 }
 
 /*
@@ -552,6 +702,11 @@ func (bot *Bot) CreatePollForChannel(chatId, question, pollType string) (*Poll,
 	return &Poll{bot: bot, pollType: pollType, chatIdString: chatId, question: question, options: make([]string, 0)}, nil
 }
 
+/*GetPoll returns the poll with the given id which has been sent by this bot. Returns nil if no such poll exists.*/
+func (bot *Bot) GetPoll(id string) *Poll {
+	return bot.polls.get(id)
+}
+
 /*
 SendDice sends a dice message to all types of chat but channels. To send it to channels use "SendDiceUN" method.
 
@@ -710,6 +865,43 @@ func (bot *Bot) GetFile(fileId string, download bool, file *os.File) (*objs.File
 	return res.Result, nil
 }
 
+/*
+DownloadTo gets the file object of the given file id and writes the content of the file into the given writer. The writer is not closed. Returns the file object on success.
+
+The limits in the "DownloadConfigs" of the bot are applied to the download. Both getting the file object and downloading the file are done with the given context.
+*/
+func (bot *Bot) DownloadTo(ctx context.Context, fileId string, w io.Writer) (*objs.File, error) {
+	api := bot.apiInterface.WithContext(ctx)
+	res, err := api.GetFile(fileId)
+	if err != nil {
+		return nil, err
+	}
+	_, err = api.DownloadTo(res.Result, w, 0)
+	return res.Result, err
+}
+
+/*
+DownloadBytes downloads the file of the given file id into the memory and returns it's content.
+
+"maxBytes" is the maximum allowed size of the file. If the file is bigger, FileTooLargeError is returned. Pass 0 to use the maximum size in the "DownloadConfigs" of the bot.
+To cancel the download, use the bot returned by "WithContext".
+*/
+func (bot *Bot) DownloadBytes(fileId string, maxBytes int64) ([]byte, error) {
+	res, err := bot.apiInterface.GetFile(fileId)
+	if err != nil {
+		return nil, err
+	}
+	buf := &bytes.Buffer{}
+	if res.Result.FileSize > 0 && res.Result.FileSize <= maxBytes {
+		buf.Grow(int(res.Result.FileSize))
+	}
+	_, err = bot.apiInterface.DownloadTo(res.Result, buf, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
 /*
 GetChatManagerById creates and returns a ChatManager for groups and other chats witch an integer id.
 
@@ -737,9 +929,37 @@ func (bot *Bot) AnswerCallbackQuery(callbackQueryId, text string, showAlert bool
 	return bot.apiInterface.AnswerCallbackQuery(callbackQueryId, text, "", showAlert, 0)
 }
 
-/*GetCommandManager returns a command manager which has several method for manaing bot commands.*/
+/*
+AddCallbackPrefixHandler adds a handler for the callback queries which their data starts with the given prefix. The params map passed to the handler is empty.
+
+Callback routes (prefix, regex and template handlers) are checked in the order they were added, after the handlers of the inline keyboards which match the exact data.
+Callback queries which don't match any handler are passed to the channels. They are answered automatically (so the button doesn't keep loading for the user) only if "AnswerUnhandledCallbacks" is true in the bot configs.
+*/
+func (bot *Bot) AddCallbackPrefixHandler(prefix string, handler func(u *objs.Update, params map[string]string)) {
+	bot.apiInterface.GetUpdateParser().AddCallbackPrefixHandler(prefix, handler)
+}
+
+/*AddCallbackRegexHandler adds a handler for the callback queries which their data matches the given regex. The values of the named groups (like "(?P<id>[0-9]+)") are passed to the handler in params.*/
+func (bot *Bot) AddCallbackRegexHandler(pattern string, handler func(u *objs.Update, params map[string]string)) error {
+	return bot.apiInterface.GetUpdateParser().AddCallbackRegexHandler(pattern, handler)
+}
+
+/*
+AddCallbackTemplateHandler adds a handler for the callback queries which their data matches the given template. Parameters are written in "{name}" format and their values are passed to the handler in params :
+
+	bot.AddCallbackTemplateHandler("item:{id}:{action}", func(u *objs.Update, params map[string]string) {
+		//For "item:42:delete", params is {"id": "42", "action": "delete"}
+	})
+
+Each parameter matches the characters until the character which comes after it in the template. The last parameter matches the rest of the data.
+*/
+func (bot *Bot) AddCallbackTemplateHandler(template string, handler func(u *objs.Update, params map[string]string)) error {
+	return bot.apiInterface.GetUpdateParser().AddCallbackTemplateHandler(template, handler)
+}
+
+/*GetCommandManager returns a command manager which has several method for manaing bot commands. The commands which have been added using "AddCommandHandler" are already in the commands list of the returned manager.*/
 func (bot *Bot) GetCommandManager() *CommandsManager {
-	return &CommandsManager{bot: bot}
+	return &CommandsManager{bot: bot, commands: bot.apiInterface.GetUpdateParser().Commands()}
 }
 
 /*
@@ -794,12 +1014,19 @@ func (bot *Bot) GetStickerSet(name string) (*StickerSet, error) {
 
 /*UploadStickerFile can be used to upload a .PNG file with a sticker for later use in CreateNewStickerSet and AddStickerToSet methods (can be used multiple times). Returns the uploaded File on success.*/
 func (bot *Bot) UploadStickerFile(userId int, stickerFormat string, eomjis, keywords []string, stickerFile *os.File) (*objs.Result[*objs.File], error) {
-	stat, err := stickerFile.Stat()
-	if err != nil {
-		return nil, err
+	if stickerFile == nil {
+		return nil, errors.New("file is nil")
+	}
+	return bot.UploadStickerInputFile(userId, stickerFormat, eomjis, keywords, objs.FileFromOSFile(stickerFile))
+}
+
+/*UploadStickerInputFile works like "UploadStickerFile" but takes an InputFile, so the sticker can be uploaded from a reader, a byte slice or a file path.*/
+func (bot *Bot) UploadStickerInputFile(userId int, stickerFormat string, eomjis, keywords []string, stickerFile *objs.InputFile) (*objs.Result[*objs.File], error) {
+	if stickerFile == nil {
+		return nil, errors.New("file is nil")
 	}
 	return bot.apiInterface.UploadStickerFile(userId, stickerFormat, &objs.InputSticker{
-		Sticker:   "attach://" + stat.Name(),
+		Sticker:   stickerFile.Value(),
 		EmojiList: eomjis,
 		KeyWords:  keywords,
 	}, stickerFile)
@@ -891,7 +1118,7 @@ func (bot *Bot) CreateStickerSet(userId int, name, title, stickerFormat, sticker
 	return &StickerSet{
 		bot:             bot,
 		initStickers:    make([]*objs.InputSticker, 0),
-		initFiles:       make([]*os.File, 0),
+		initFiles:       make([]*objs.InputFile, 0),
 		userId:          userId,
 		name:            name,
 		title:           title,
@@ -1250,10 +1477,45 @@ func (bot *Bot) VerifyJoin(userID int, UserName string) bool {
 	return err == nil
 }
 
-/*Stop stops the bot*/
+/*Stop stops the bot immediately. Use "Shutdown" to wait for the received updates to be processed.*/
 func (bot *Bot) Stop() {
 	bot.apiInterface.StopUpdateRoutine()
-	*bot.prcRoutineChannel <- true
+	bot.webhook.Shutdown(context.Background())
+	bot.stopProcessing()
+}
+
+/*
+Shutdown stops the bot gracefully. First the bot stops receiving updates : the update routine is stopped, or the webhook stops accepting new requests (they are answered with 503 status code, so the api server sends them again later).
+Then it waits for all the running middlewares and handlers to return. When polling, the received updates are confirmed to the api server after the handlers have returned.
+The bot is stopped after that or when the context is cancelled, whichever happens first. Returns the context's error if the context is cancelled before the handlers return.
+
+Note that the handlers should not wait for new updates (for example by reading the update channels) since no more updates are received.
+*/
+func (bot *Bot) Shutdown(ctx context.Context) error {
+	var err error
+	if bot.botCfg.Webhook {
+		err = bot.webhook.Shutdown(ctx)
+	} else {
+		err = bot.apiInterface.StopUpdateRoutineCtx(ctx)
+	}
+	if err2 := bot.apiInterface.GetUpdateParser().Wait(ctx); err == nil {
+		err = err2
+	}
+	bot.stopProcessing()
+	return err
+}
+
+/*Stops the routines which pass the updates to the channels. Closing the channel stops both of the routines.*/
+func (bot *Bot) stopProcessing() {
+	bot.runMx.Lock()
+	defer bot.runMx.Unlock()
+	if *bot.prcRoutineChannel != nil {
+		close(*bot.prcRoutineChannel)
+		*bot.prcRoutineChannel = nil
+		(*bot.stopCfgRoutine)()
+		*bot.stopCfgRoutine = nil
+	}
+	bot.apiInterface.GetUpdateParser().Close()
 }
 
 /*AdvancedMode returns and advanced version of the bot which gives more customized functions to iteract with the bot*/
@@ -1277,11 +1539,11 @@ func (bot *Bot) processUpdate(update *objs.Update, mapKey string) bool {
 	return out
 }
 
-func (bot *Bot) startUpdateProcessing() {
+func (bot *Bot) startUpdateProcessing(stop chan bool) {
 loop:
 	for {
 		select {
-		case <-*bot.prcRoutineChannel:
+		case <-stop:
 			break loop
 		case up := <-*bot.interfaceUpdateChannel:
 			if !bot.processUpdate(up, "global") {
@@ -1293,23 +1555,27 @@ loop:
 
 func (bot *Bot) processPoll(update *objs.Update) {
 	id := update.Poll.Id
-	pl := Polls[id]
+	pl := bot.polls.get(id)
 	if pl == nil {
-		bot.logger.Log("Error", "\t\t\t", "Could not update poll `"+id+"`. Not found in the Polls map", "917", logger.BOLD+logger.FAIL, logger.WARNING, "")
+		bot.logger.Log("Error", "\t\t\t", "Could not update poll `"+id+"`. Not found in the polls of the bot", "917", logger.BOLD+logger.FAIL, logger.WARNING, "")
 		*bot.channelsMap["global"]["all"] <- update
 	} else {
 		err3 := pl.Update(update.Poll)
 		if err3 != nil {
 			bot.logger.Log("Error", "\t\t\t", "Could not update poll `"+id+"`."+err3.Error(), "922", logger.BOLD+logger.FAIL, logger.WARNING, "")
 		}
+		//Closed polls don't receive any more updates.
+		if update.Poll.IsClosed {
+			bot.polls.remove(id)
+		}
 	}
 }
 
-func (bot *Bot) startChatUpdateRoutine() {
+func (bot *Bot) startChatUpdateRoutine(stop chan bool) {
 loop:
 	for {
 		select {
-		case <-*bot.prcRoutineChannel:
+		case <-stop:
 			break loop
 		case up := <-*bot.chatUpdateChannel:
 			if !bot.processUpdate(up.Update, up.ChatId) {
@@ -1326,6 +1592,16 @@ loop:
 
 /*NewBot returns a new bot instance with the specified configs*/
 func NewBot(cfg *cfg.BotConfigs) (*Bot, error) {
+	return NewBotWithAPI(cfg, nil)
+}
+
+/*
+NewBotWithAPI returns a new bot instance with the specified configs which uses the given api to communicate with the bot api server.
+If api is nil, the default api (tba.BotAPIInterface) is created.
+
+This can be used to test the code that uses the bot with a fake api, such as the mock in "tba/tbamock" package.
+*/
+func NewBotWithAPI(cfg *cfg.BotConfigs, api tba.API) (*Bot, error) {
 	if cfg == nil {
 		return nil, errors.New("cfg is nil")
 	}
@@ -1333,11 +1609,15 @@ func NewBot(cfg *cfg.BotConfigs) (*Bot, error) {
 		return nil, errors.New("config check failed. Please check the configs")
 	}
 	botLogger := logger.InitTheLogger(cfg)
-	api, err := tba.CreateInterface(cfg, botLogger)
-	if err != nil {
-		return nil, err
+	if api == nil {
+		var err error
+		api, err = tba.CreateInterface(cfg, botLogger)
+		if err != nil {
+			return nil, err
+		}
 	}
-	ch := make(chan bool)
+	//The channel is created when the bot starts running.
+	var ch chan bool
 	uc := make(chan *objs.Update)
 	bt := &Bot{botCfg: cfg,
 		apiInterface:           api,
@@ -1346,7 +1626,29 @@ func NewBot(cfg *cfg.BotConfigs) (*Bot, error) {
 		prcRoutineChannel:      &ch,
 		channelsMap:            make(map[string]map[string]*chan *objs.Update),
 		logger:                 botLogger,
+		polls:                  &pollStore{internal: make(map[string]*Poll)},
+		webhook:                tba.NewWebhook(cfg, api.GetUpdateParser(), botLogger),
+		runMx:                  &sync.Mutex{},
+		stopCfgRoutine:         new(context.CancelFunc),
 	}
+	if bai, ok := api.(*tba.BotAPIInterface); ok {
+		bai.UseWebhookReplies(bt.webhook)
+	}
+	api.GetUpdateParser().SetUsernameResolver(func() (string, error) {
+		res, err := api.GetMe()
+		if err != nil {
+			return "", err
+		}
+		if res.Result == nil {
+			return "", errors.New("getMe returned no user")
+		}
+		return res.Result.Username, nil
+	})
+	api.GetUpdateParser().SetCallbackAnswerer(func(callbackQueryId string) {
+		if _, err := api.AnswerCallbackQuery(callbackQueryId, "", "", false, 0); err != nil {
+			botLogger.GetRaw().Println("Unable to answer the unhandled callback query.", err)
+		}
+	})
 	bt.channelsMap["global"] = make(map[string]*chan *objs.Update)
 	bt.channelsMap["global"]["all"] = &uc
 	bt.ab = &AdvancedBot{bot: bt}
@@ -0,0 +1,162 @@
+package telego
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+/*
+BotGroup is a group of bots which are started and stopped together. It's useful when many bots are hosted in one process.
+
+Every bot of the group has it's own configs, handlers, middlewares, channels and polls. Bots in a group should not use the same config file.
+*/
+type BotGroup struct {
+	mx      sync.Mutex
+	bots    []*Bot
+	running bool
+	ctx     context.Context
+}
+
+/*NewBotGroup returns a group containing the given bots.*/
+func NewBotGroup(bots ...*Bot) (*BotGroup, error) {
+	bg := &BotGroup{}
+	for _, bot := range bots {
+		if err := bg.Add(bot); err != nil {
+			return nil, err
+		}
+	}
+	return bg, nil
+}
+
+/*
+Add adds the given bot to the group. If the group is running, the bot is started too.
+
+Returns an error if the bot uses the same config file as another bot of the group, because the configs of the bots would be overwritten by each other.
+*/
+func (bg *BotGroup) Add(bot *Bot) error {
+	if bot == nil {
+		return errors.New("bot is nil")
+	}
+	bg.mx.Lock()
+	for _, b := range bg.bots {
+		if b == bot {
+			bg.mx.Unlock()
+			return errors.New("bot has already been added to the group")
+		}
+		if b.botCfg.ConfigFile != "" && b.botCfg.ConfigFile == bot.botCfg.ConfigFile {
+			bg.mx.Unlock()
+			return errors.New("config file \"" + bot.botCfg.ConfigFile + "\" is used by another bot of the group")
+		}
+	}
+	bg.bots = append(bg.bots, bot)
+	running, ctx := bg.running, bg.ctx
+	bg.mx.Unlock()
+	if !running {
+		return nil
+	}
+	//The bot is started outside of the lock so the other methods of the group are not blocked while it's starting.
+	if err := bot.RunCtx(ctx, false); err != nil {
+		bg.remove(bot)
+		return err
+	}
+	bg.mx.Lock()
+	defer bg.mx.Unlock()
+	if !bg.running {
+		//The group has been stopped while the bot was starting.
+		bot.Stop()
+	}
+	return nil
+}
+
+func (bg *BotGroup) remove(bot *Bot) {
+	bg.mx.Lock()
+	defer bg.mx.Unlock()
+	for i, b := range bg.bots {
+		if b == bot {
+			bg.bots = append(bg.bots[:i], bg.bots[i+1:]...)
+			return
+		}
+	}
+}
+
+/*Bots returns the bots of this group.*/
+func (bg *BotGroup) Bots() []*Bot {
+	bg.mx.Lock()
+	defer bg.mx.Unlock()
+	out := make([]*Bot, len(bg.bots))
+	copy(out, bg.bots)
+	return out
+}
+
+/*
+Run starts all the bots of the group. The update routines of the bots are bound to the given context. If one of the bots fails to start, the bots which have been started are stopped and the error is returned.
+
+If "autoPause" is true, this method blocks until the given context is cancelled and then it stops all the bots.
+*/
+func (bg *BotGroup) Run(ctx context.Context, autoPause bool) error {
+	bg.mx.Lock()
+	if bg.running {
+		bg.mx.Unlock()
+		return errors.New("bot group is already running")
+	}
+	bg.running = true
+	bg.ctx = ctx
+	bots := make([]*Bot, len(bg.bots))
+	copy(bots, bg.bots)
+	bg.mx.Unlock()
+	for i, bot := range bots {
+		if err := bot.RunCtx(ctx, false); err != nil {
+			for _, started := range bots[:i] {
+				started.Stop()
+			}
+			bg.mx.Lock()
+			bg.running = false
+			bg.mx.Unlock()
+			return err
+		}
+	}
+	if autoPause {
+		<-ctx.Done()
+		bg.Stop()
+	}
+	return nil
+}
+
+/*Stop stops all the bots of the group.*/
+func (bg *BotGroup) Stop() {
+	for _, bot := range bg.stop() {
+		bot.Stop()
+	}
+}
+
+/*Shutdown shuts down all the bots of the group gracefully and at the same time. See "Shutdown" method of the bot for more info. Returns the first error returned by the bots.*/
+func (bg *BotGroup) Shutdown(ctx context.Context) error {
+	bots := bg.stop()
+	errs := make(chan error, len(bots))
+	for _, bot := range bots {
+		go func(bot *Bot) {
+			errs <- bot.Shutdown(ctx)
+		}(bot)
+	}
+	var out error
+	for range bots {
+		if err := <-errs; err != nil && out == nil {
+			out = err
+		}
+	}
+	return out
+}
+
+/*stop marks the group as stopped and returns the bots which should be stopped. The bots are stopped outside of the lock so the handlers can still use the group.*/
+func (bg *BotGroup) stop() []*Bot {
+	bg.mx.Lock()
+	defer bg.mx.Unlock()
+	if !bg.running {
+		return nil
+	}
+	bg.running = false
+	bots := make([]*Bot, len(bg.bots))
+	copy(bots, bg.bots)
+	return bots
+}
@@ -0,0 +1,118 @@
+package telego_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	telego "github.com/SakoDroid/telego/v2"
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+/*Creates a bot connected to the given server which replies "pong" to "ping".*/
+func newPingBot(t *testing.T, srv *telegotest.Server, configure ...func(cfg *configs.BotConfigs)) *telego.Bot {
+	t.Helper()
+	cfg := srv.Configs()
+	for _, f := range configure {
+		f(cfg)
+	}
+	bot, err := telego.NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot.AddHandler("ping", func(u *objs.Update) {
+		bot.SendMessage(u.Message.Chat.Id, "pong", "", 0, false, false, nil)
+	}, "all")
+	return bot
+}
+
+func TestBotGroupAdd(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	var file string
+	first := newPingBot(t, srv, func(cfg *configs.BotConfigs) {
+		file = cfg.ConfigFile
+	})
+	bg, err := telego.NewBotGroup(first)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bg.Add(nil); err == nil {
+		t.Error("nil bot has been added")
+	}
+	if err := bg.Add(first); err == nil {
+		t.Error("bot has been added twice")
+	}
+	second := newPingBot(t, srv, func(cfg *configs.BotConfigs) {
+		cfg.ConfigFile = file
+	})
+	if err := bg.Add(second); err == nil {
+		t.Error("bot with the same config file has been added")
+	}
+	if len(bg.Bots()) != 1 {
+		t.Fatal("wrong number of bots :", len(bg.Bots()))
+	}
+}
+
+func TestBotGroupRunAndStop(t *testing.T) {
+	first, second, third := telegotest.NewServer(t), telegotest.NewServer(t), telegotest.NewServer(t)
+	bg, err := telego.NewBotGroup(newPingBot(t, first), newPingBot(t, second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bg.Run(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+	defer bg.Stop()
+	if err := bg.Run(context.Background(), false); err == nil {
+		t.Error("group has been started twice")
+	}
+	first.ExpectReply(t, first.SendText(10, 10, "ping"), "pong")
+	second.ExpectReply(t, second.SendText(10, 10, "ping"), "pong")
+	//Bots which are added to a running group are started right away.
+	if err := bg.Add(newPingBot(t, third)); err != nil {
+		t.Fatal(err)
+	}
+	third.ExpectReply(t, third.SendText(10, 10, "ping"), "pong")
+	bg.Stop()
+	time.Sleep(50 * time.Millisecond)
+	first.SendText(10, 10, "ping")
+	first.ExpectNoCall(t, 200*time.Millisecond, "sendMessage")
+}
+
+func TestBotGroupShutdown(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := newPingBot(t, srv)
+	bg, err := telego.NewBotGroup(bot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bg.Shutdown(context.Background()); err != nil {
+		t.Error("shutting down a stopped group failed :", err)
+	}
+	if err := bg.Run(context.Background(), false); err != nil {
+		t.Fatal(err)
+	}
+	started := make(chan struct{})
+	bot.AddHandler("slow", func(u *objs.Update) {
+		close(started)
+		//Handlers can use the group while it's shutting down.
+		time.Sleep(50 * time.Millisecond)
+		bg.Bots()
+		time.Sleep(100 * time.Millisecond)
+		bot.SendMessage(u.Message.Chat.Id, "done", "", 0, false, false, nil)
+	}, "all")
+	srv.SendText(10, 10, "slow")
+	select {
+	case <-started:
+	case <-time.After(srv.Timeout):
+		t.Fatal("handler was not executed")
+	}
+	if err := bg.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Calls("sendMessage")) != 1 {
+		t.Error("shutdown did not wait for the handler")
+	}
+}
@@ -0,0 +1,32 @@
+package telego_test
+
+import (
+	"context"
+	"testing"
+
+	telego "github.com/SakoDroid/telego/v2"
+	"github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+/*Creates a bot connected to the given fake server and runs it until the test ends. The given functions can change the configs before the bot is created.*/
+func startBot(t *testing.T, srv *telegotest.Server, configure ...func(cfg *configs.BotConfigs)) *telego.Bot {
+	t.Helper()
+	cfg := srv.Configs()
+	for _, f := range configure {
+		f(cfg)
+	}
+	bot, err := telego.NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		bot.Stop()
+	})
+	if err := bot.RunCtx(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	return bot
+}
@@ -0,0 +1,54 @@
+package telego_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+func TestCallbackRoutes(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv, func(cfg *configs.BotConfigs) {
+		cfg.AnswerUnhandledCallbacks = true
+	})
+	err := bot.AddCallbackTemplateHandler("item:{id}:{action}", func(u *objs.Update, params map[string]string) {
+		bot.AnswerCallbackQuery(u.CallbackQuery.Id, params["action"]+" "+params["id"], false)
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bot.AddCallbackRegexHandler(`^page-(?P<number>\d+)$`, func(u *objs.Update, params map[string]string) {
+		bot.AnswerCallbackQuery(u.CallbackQuery.Id, "page "+params["number"], false)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	bot.AddCallbackPrefixHandler("menu", func(u *objs.Update, params map[string]string) {
+		bot.AnswerCallbackQuery(u.CallbackQuery.Id, "menu", false)
+	})
+
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(-100, 10, 5, "item:42:delete"), "delete 42")
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(-100, 10, 5, "page-3"), "page 3")
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(-100, 10, 5, "menu/settings"), "menu")
+	//Callback queries which don't match any route are answered automatically when "AnswerUnhandledCallbacks" is true.
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(-100, 10, 5, "item:42"), "")
+}
+
+func TestUnhandledCallbacksAreNotAnswered(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	bot.AddCallbackPrefixHandler("menu", func(u *objs.Update, params map[string]string) {})
+	//The query is passed to the channel, where it can be answered.
+	query := srv.SendCallback(-100, 10, 5, "item:42")
+	select {
+	case u := <-*bot.GetUpdateChannel():
+		if u.Update_id != query.Update_id {
+			t.Fatalf("unexpected update in the channel : %d", u.Update_id)
+		}
+	case <-time.After(srv.Timeout):
+		t.Fatal("the unhandled callback query was not passed to the channel")
+	}
+	srv.ExpectNoCall(t, 100*time.Millisecond, "answerCallbackQuery")
+}
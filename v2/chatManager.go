@@ -144,6 +144,13 @@ func (cm *ChatManager) DeclineJoinRequest(userId int) (*objs.Result[bool], error
 
 /*SetPhoto can be used to set a new profile photo for the chat. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.*/
 func (cm *ChatManager) SetPhoto(photoFile *os.File) (*objs.Result[bool], error) {
+	return cm.bot.apiInterface.SetChatPhoto(
+		cm.chatIdInt, cm.chatIdString, objs.FileFromOSFile(photoFile),
+	)
+}
+
+/*SetPhotoInputFile works like "SetPhoto" but takes an InputFile, so the photo can be uploaded from a reader, a byte slice or a file path.*/
+func (cm *ChatManager) SetPhotoInputFile(photoFile *objs.InputFile) (*objs.Result[bool], error) {
 	return cm.bot.apiInterface.SetChatPhoto(
 		cm.chatIdInt, cm.chatIdString, photoFile,
 	)
@@ -2,10 +2,15 @@ package telego
 
 import (
 	"errors"
+	"regexp"
+	"strings"
 
 	objs "github.com/SakoDroid/telego/v2/objects"
 )
 
+/*The valid names of the commands. Commands can contain lower case english letters, digits and underscores and can be 1-32 characters long.*/
+var commandNameRegex = regexp.MustCompile(`^[a-z0-9_]{1,32}$`)
+
 // CommandsManager is a tool for managing bot commands
 type CommandsManager struct {
 	bot      *Bot
@@ -91,3 +96,41 @@ func (cm *CommandsManager) GetCommands(languageCode string) ([]objs.BotCommand,
 	cm.commands = res.Result
 	return cm.commands, nil
 }
+
+/*HelpText returns the commands list in "/command - description" format, one command per line. It can be used as the reply of the /help command.*/
+func (cm *CommandsManager) HelpText() string {
+	lines := make([]string, len(cm.commands))
+	for i, cmd := range cm.commands {
+		lines[i] = "/" + cmd.Command + " - " + cmd.Description
+	}
+	return strings.Join(lines, "\n")
+}
+
+/*
+AddCommandHandler adds a handler for the given command. Commands are routed using the bot_command entities of the messages (or captions), so the handler is executed for "/name", "/Name@botusername" and
+"/name@botusername arguments". Commands which are sent to other bots in groups (with "@otherbot" suffix) are ignored.
+
+The arguments are split shell style (quotes group the words and backslash escapes a character) and passed to the handler with the command :
+
+	bot.AddCommandHandler("ban", "Ban a user", func(u *objs.Update, cmd *objs.Command) {
+		//For "/ban 123 'too much spam'", cmd.Args is ["123", "too much spam"]
+	}, "group", "supergroup")
+
+"name" should not contain the "/" prefix. If "description" is not empty, the command is added to the commands list of the managers returned by "GetCommandManager", so it can be set using "SetCommands".
+"chatTypes" can contain "private","group","supergroup","channel" or "all". If no chat type is given, the handler acts on all chats.
+Command handlers are checked before the text handlers added by "AddHandler".
+*/
+func (bot *Bot) AddCommandHandler(name, description string, handler func(*objs.Update, *objs.Command), chatTypes ...string) error {
+	name = strings.ToLower(strings.TrimPrefix(name, "/"))
+	if !commandNameRegex.MatchString(name) {
+		return errors.New("invalid command name : " + name)
+	}
+	if len(description) > 256 {
+		return errors.New("command description can not be longer than 256 characters")
+	}
+	if err := checkChatTypes(chatTypes); err != nil {
+		return err
+	}
+	bot.apiInterface.GetUpdateParser().AddCommandHandler(name, description, handler, chatTypes...)
+	return nil
+}
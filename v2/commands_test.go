@@ -0,0 +1,33 @@
+package telego_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+func TestCommandRouter(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	err := bot.AddCommandHandler("/Ban", "Ban a user", func(u *objs.Update, cmd *objs.Command) {
+		bot.SendMessage(u.Message.Chat.Id, cmd.Name+" "+strings.Join(cmd.Args, "|")+" "+cmd.RawArgs, "", 0, false, false, nil)
+	}, "supergroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bot.AddCommandHandler("not a command", "", nil); err == nil {
+		t.Fatal("invalid command names should be rejected")
+	}
+	if help := bot.GetCommandManager().HelpText(); help != "/ban - Ban a user" {
+		t.Fatalf("unexpected help text : %q", help)
+	}
+
+	srv.ExpectReply(t, srv.SendText(-10, 10, `/BAN@Test_Bot 123 "too much spam"`), `ban 123|too much spam 123 "too much spam"`)
+	//Commands of other bots and commands in chats which the handler doesn't act on are ignored.
+	srv.SendText(-10, 10, "/ban@other_bot 123")
+	srv.SendText(10, 10, "/ban 123")
+	srv.ExpectNoCall(t, 100*time.Millisecond, "sendMessage")
+}
@@ -1,10 +1,16 @@
 package configs
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
+	"net/http"
 	"os"
+	"runtime"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -21,6 +27,12 @@ const DefaultBotAPI = "https://api.telegram.org/bot"
 // DefaultLogFile is a default file for saving the bot logs in it.
 const DefaultLogFile = "STDOUT"
 
+// DefaultWebhookCertFile is the default file for saving the generated self signed certificate of the webhook.
+const DefaultWebhookCertFile = "webhook_cert.pem"
+
+// DefaultWebhookKeyFile is the default file for saving the private key of the generated self signed certificate.
+const DefaultWebhookKeyFile = "webhook_key.pem"
+
 // BotConfigs is a struct holding the bots configs.
 type BotConfigs struct {
 	/*Name is the bot's custom name. This is used in logging*/
@@ -29,6 +41,10 @@ type BotConfigs struct {
 	BotAPI string `json:"bot_api"`
 	/*The API key for your bot. You can get the api key (token) from botfather*/
 	APIKey string `json:"api_key"`
+	/*Set this field to true if "BotAPI" is a local bot api server which has been started with "--local" option.
+	In local mode the file paths returned by getFile are absolute paths on the server's file system and files are read directly from them instead of being downloaded.
+	Local files can also be sent using "objects.FileFromServerPath" without uploading them.*/
+	LocalMode bool `json:"local_mode"`
 	/*The settings related to getting updates from the api server. This field shoud only be populated when Webhook field is false, otherwise it is ignored.*/
 	UpdateConfigs *UpdateConfigs `json:"update_configs,omitempty"`
 	/*This field idicates if webhook should be used for receiving updates or not.
@@ -44,6 +60,40 @@ type BotConfigs struct {
 	Fixing ISSUE #13
 	*/
 	ConfigFile string `json:"config_name"`
+	/*HttpClient is the http client used for all the requests sent to the bot api server (including getUpdates and file downloads).
+	Use this field to set timeouts, proxies or a custom transport. If nil, a default client is created for the bot and reused for all requests.
+	This field is not saved in the config file.*/
+	HttpClient *http.Client `json:"-"`
+	/*The settings related to retrying the failed requests. If nil, failed requests are not retried.*/
+	RetryConfigs *RetryConfigs `json:"retry_configs,omitempty"`
+	/*The settings related to limiting the rate of the outgoing messages. If nil, messages are sent without any limit.*/
+	RateLimitConfigs *RateLimitConfigs `json:"rate_limit_configs,omitempty"`
+	/*The settings related to downloading files. If nil, files are downloaded without any limit.*/
+	DownloadConfigs *DownloadConfigs `json:"download_configs,omitempty"`
+	/*The settings of the update dispatcher. If set, the received updates are processed by a limited number of workers and updates of the same chat (or user) are processed in the order they were received.
+	The handlers run in the workers, so a handler must not wait for a later update of it's own chat (for example by reading it's chat channel), otherwise the worker is blocked forever.
+	If nil, every update is processed in a new goroutine.*/
+	DispatcherConfigs *DispatcherConfigs `json:"dispatcher_configs,omitempty"`
+	/*OnError is called when a handler or a middleware panics. The panic is recovered and the error is a *errors.PanicError which contains the panic value, the stack trace and the update.
+	If nil, the error is written in the logs of the bot. See "LogError" and "ReportErrorTo" methods of the bot for the built-in hooks.
+	This field is not saved in the config file.*/
+	OnError func(err error) `json:"-"`
+	/*If true, the raw json of the received updates is kept, so it can be accessed using "Raw" method of the updates. Enable it when the updates are recorded using the recorder package, so the recorded updates contain the fields which are unknown to this library.
+	Keeping the raw json increases the memory used for each update, so it's disabled by default.*/
+	KeepRawUpdates bool `json:"keep_raw_updates,omitempty"`
+	/*The duration the data of the typed callback buttons which don't fit in the callback data (64 bytes) is kept by the bot. Defaults to 24 hours.*/
+	CallbackDataTTL time.Duration `json:"callback_data_ttl,omitempty"`
+	/*CallbackDataStore keeps the data of the typed callback buttons which don't fit in the callback data. If nil, the data is kept in memory, so the buttons which carry stored data
+	stop working after the bot is restarted. Use this field to keep the data somewhere else (for example a database). This field is not saved in the config file.*/
+	CallbackDataStore CallbackDataStore `json:"-"`
+	/*If true, the callback queries which don't match any callback handler or route are answered automatically with an empty answer, so the button doesn't keep loading for the user.
+	These queries are still passed to the channels, so this option should not be enabled if the callback queries are answered from the channels.*/
+	AnswerUnhandledCallbacks bool `json:"answer_unhandled_callbacks,omitempty"`
+}
+
+/*FileAPI returns the address which files are downloaded from. It is derived from "BotAPI", so it points to the same server. (for example "https://api.telegram.org/file/bot")*/
+func (bc *BotConfigs) FileAPI() string {
+	return strings.TrimSuffix(bc.BotAPI, "bot") + "file/bot"
 }
 
 // Check checks the bot configs for any problem.
@@ -70,13 +120,35 @@ func (bc *BotConfigs) Check() bool {
 
 // StartCfgUpdateRoutine starts a routine which updates the configs every second.
 func (bc *BotConfigs) StartCfgUpdateRoutine() {
-	for {
-		err := LoadInto(bc)
+	bc.StartCfgUpdateRoutineCtx(context.Background())
+}
+
+/*
+StartCfgUpdateRoutineCtx works like "StartCfgUpdateRoutine" but it returns when the given context is cancelled. The config file is checked every second and the configs are reloaded only when the file has been modified.
+The bot starts this routine when it runs and stops it when the bot is stopped.
+*/
+func (bc *BotConfigs) StartCfgUpdateRoutineCtx(ctx context.Context) {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	var modTime time.Time
+	for first := true; ; first = false {
+		st, err := os.Stat(bc.ConfigFile)
+		if err == nil && !st.ModTime().Equal(modTime) {
+			//The configs are up to date when the routine starts.
+			if !first {
+				err = LoadInto(bc)
+			}
+			modTime = st.ModTime()
+		}
 		if err != nil {
 			println("Error in \"StartCfgUpdateRoutine\" function.", err.Error())
-			break
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
 		}
-		time.Sleep(time.Second)
 	}
 }
 
@@ -146,8 +218,11 @@ type WebHookConfigs struct {
 	KeyFile string `json:"keyfile"`
 	/*The address of the certificate file.*/
 	CertFile string `json:"certfile"`
-	/*Is your certificate self signed?*/
+	/*Is your certificate self signed? If true and the certificate or key file doesn't exist, a self signed certificate is generated for the host (or IP address) of the webhook url and saved in these files.
+	The generated certificate is uploaded to the api server and is renewed automatically before it expires. The files default to "webhook_cert.pem" and "webhook_key.pem".*/
 	SelfSigned bool
+	/*The key type of the generated self signed certificate. "RSA" (2048 bits) and "ECDSA" (P-256) are supported. Defaults to "RSA".*/
+	CertKeyType string `json:"cert_key_type,omitempty"`
 	/*The fixed IP address which will be used to send webhook requests instead of the IP address resolved through DNS*/
 	IP string `json:"ip,omitempty"`
 	/*Maximum allowed number of simultaneous HTTPS connections to the webhook for update delivery, 1-100. Defaults to 40. Use lower values to limit the load on your bot's server, and higher values to increase your bot's throughput.*/
@@ -159,22 +234,49 @@ type WebHookConfigs struct {
 	DropPendingUpdates bool `json:"drop_pending_reqs"`
 	/*A secret token to be sent in a header “X-Telegram-Bot-Api-Secret-Token” in every webhook request, 1-256 characters. Only characters A-Z, a-z, 0-9, _ and - are allowed. The header is useful to ensure that the request comes from a webhook set by you.*/
 	SecretToken string `json:"secret_token,omitempty"`
+	/*If true, the webhook server serves plain HTTP instead of HTTPS. This should be used when TLS is terminated by a reverse proxy (such as nginx) which forwards the requests to the bot. Port defaults to 80 in this mode.*/
+	PlainHTTP bool `json:"plain_http,omitempty"`
+	/*If true, the bot does not start a server for the webhook. The webhook handler returned by "WebhookHandler" method of the bot should be mounted on an existing http server at the path of the webhook url.
+	The url is used as it is in this mode and the api key is not added to it, so setting "SecretToken" is recommended.*/
+	ExternalServer bool `json:"external_server,omitempty"`
+	/*The tls config of the webhook server. If "CertFile" and "KeyFile" are set, their certificate is added to the certificates of this config. If this field is set, the certificate and key files are optional.*/
+	TLSConfig *tls.Config `json:"-"`
+	/*If true, the first api call which is sent for an update using the bot returned by "ReplyInWebhook" method of the bot is sent in the response of the webhook request instead of a separate request, which saves a round trip.
+	The response is written as soon as the handlers of the update return, so only the calls which are sent before that (and before "ReplyTimeout") are sent in the response, the rest are sent normally.
+	Calls which upload files are always sent normally.*/
+	ReplyInResponse bool `json:"reply_in_response,omitempty"`
+	/*The maximum duration the webhook waits for the handlers of an update when "ReplyInResponse" is true. Defaults to one second.*/
+	ReplyTimeout time.Duration `json:"reply_timeout,omitempty"`
 }
 
 func (whc *WebHookConfigs) check(apiKey string) bool {
 	if whc.URL == "" {
 		return false
 	}
-	if whc.KeyFile == "" {
-		return false
+	if whc.SelfSigned {
+		if whc.CertFile == "" {
+			whc.CertFile = DefaultWebhookCertFile
+		}
+		if whc.KeyFile == "" {
+			whc.KeyFile = DefaultWebhookKeyFile
+		}
 	}
-	if whc.CertFile == "" {
-		return false
+	if !whc.ExternalServer && !whc.PlainHTTP && whc.TLSConfig == nil {
+		if whc.KeyFile == "" {
+			return false
+		}
+		if whc.CertFile == "" {
+			return false
+		}
 	}
 	if whc.Port == 0 {
-		whc.Port = 443
+		if whc.PlainHTTP {
+			whc.Port = 80
+		} else {
+			whc.Port = 443
+		}
 	}
-	if !strings.HasSuffix(whc.URL, apiKey) {
+	if !whc.ExternalServer && !strings.HasSuffix(whc.URL, apiKey) {
 		if !strings.HasSuffix(whc.URL, "/") {
 			whc.URL += "/"
 		}
@@ -194,6 +296,143 @@ type UpdateConfigs struct {
 	AllowedUpdates []string `json:"allowed_updates,omitempty"`
 	/*This field indicates the frequency to call getUpdates method. Default is one second*/
 	UpdateFrequency time.Duration `json:"update_freq"`
+	/*If not empty, the offset of the processed updates is saved in this file, so the bot continues from the same update after restarting. Ignored if "OffsetStore" is set.*/
+	OffsetFile string `json:"offset_file,omitempty"`
+	/*OffsetStore saves and loads the offset of the processed updates. Use this field to keep the offset somewhere other than a file (for example a database).
+	This field is not saved in the config file.*/
+	OffsetStore OffsetStore `json:"-"`
+	/*If true, the offset is advanced only after the middleware chain and the handlers of the updates have returned, so the api server sends the updates which were not processed because of a crash or restart again.
+	The offset is held before the oldest update which is still being processed, so the updates after it may be processed again after restarting, but an update is never processed twice while the bot is running.
+	A handler which doesn't return holds back the offset : when more than "Limit" updates are waiting after it's update, no new updates are received until it returns.*/
+	AtLeastOnce bool `json:"at_least_once,omitempty"`
+}
+
+/*CallbackDataStore keeps the data of the callback buttons which is too long to be sent with the buttons. The buttons contain a short key instead.*/
+type CallbackDataStore interface {
+	/*Save saves the data under the given key. The data is not needed after the given ttl and it can be removed.*/
+	Save(key, data string, ttl time.Duration) error
+	/*Load returns the data saved under the given key. It should return false if the key doesn't exist or the data has expired.*/
+	Load(key string) (string, bool, error)
+}
+
+/*OffsetStore keeps the offset of the updates received by polling. The offset is the id of the last update which has been received (or processed, in at least once mode).*/
+type OffsetStore interface {
+	/*Load returns the saved offset. It should return 0 if no offset has been saved yet.*/
+	Load() (int, error)
+	/*Save saves the given offset.*/
+	Save(offset int) error
+}
+
+/*FileOffsetStore is an OffsetStore which keeps the offset in a file.*/
+type FileOffsetStore struct {
+	path string
+}
+
+/*NewFileOffsetStore returns an offset store which keeps the offset in the given file. The file is created when the offset is saved for the first time.*/
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{path: path}
+}
+
+/*Load reads the offset from the file. Returns 0 if the file doesn't exist.*/
+func (fs *FileOffsetStore) Load() (int, error) {
+	data, err := os.ReadFile(fs.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return strconv.Atoi(strings.TrimSpace(string(data)))
+}
+
+/*Save writes the offset in the file. The offset is written in a temporary file first and then the file is replaced, so the saved offset is never corrupted.*/
+func (fs *FileOffsetStore) Save(offset int) error {
+	return writeFileAtomic(fs.path, []byte(strconv.Itoa(offset)))
+}
+
+/*Writes the data in a temporary file first and then replaces the file with it, so the file is never corrupted.*/
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// RetryConfigs contains the configs related to retrying the requests that have failed.
+type RetryConfigs struct {
+	/*Maximum number of times a failed request is retried. 0 disables retrying.*/
+	MaxRetries int `json:"max_retries"`
+	/*If true, the request is retried after waiting for "retry_after" seconds when api server responds with 429 (flood control).*/
+	RetryOnFloodControl bool `json:"retry_on_flood_control"`
+	/*Requests that hit the flood control are not retried if api server asks to wait longer than this duration. 0 means no limit.*/
+	MaxRetryAfter time.Duration `json:"max_retry_after"`
+	/*If true, the request is retried with backoff when api server responds with 5xx status codes or when a network error occurs.*/
+	RetryOnServerErrors bool `json:"retry_on_server_errors"`
+	/*The initial wait before retrying a request that has failed because of server or network errors. It is doubled after each retry. Defaults to one second.*/
+	Backoff time.Duration `json:"backoff"`
+	/*Maximum wait between two retries caused by server or network errors. Defaults to 30 seconds.*/
+	MaxBackoff time.Duration `json:"max_backoff"`
+	/*If true, when api server responds with "migrate_to_chat_id" (the group has been upgraded to a supergroup), the request is sent again to the new chat. Only methods that send messages can be re-targeted.*/
+	FollowChatMigration bool `json:"follow_chat_migration"`
+}
+
+// DefaultRetryConfigs returns a retry config that handles flood control, server errors and chat migrations with at most 3 retries.
+func DefaultRetryConfigs() *RetryConfigs {
+	return &RetryConfigs{
+		MaxRetries:          3,
+		RetryOnFloodControl: true,
+		RetryOnServerErrors: true,
+		Backoff:             time.Second,
+		MaxBackoff:          30 * time.Second,
+		FollowChatMigration: true,
+	}
+}
+
+// RateLimitConfigs contains the configs of the outgoing rate limiter. The limits are only applied to the methods which send a message to a chat.
+type RateLimitConfigs struct {
+	/*Maximum number of messages sent per second to all chats. Pass 0 to disable this limit.*/
+	GlobalPerSecond int `json:"global_per_second"`
+	/*Maximum number of messages sent per second to a single private chat. Pass 0 to disable this limit.*/
+	PrivateChatPerSecond int `json:"private_chat_per_second"`
+	/*Maximum number of messages sent per minute to a single group, supergroup or channel. Pass 0 to disable this limit.*/
+	GroupPerMinute int `json:"group_per_minute"`
+}
+
+// DefaultRateLimitConfigs returns the limits documented by telegram : 30 messages per second globally, 1 message per second for each private chat and 20 messages per minute for each group.
+func DefaultRateLimitConfigs() *RateLimitConfigs {
+	return &RateLimitConfigs{
+		GlobalPerSecond:      30,
+		PrivateChatPerSecond: 1,
+		GroupPerMinute:       20,
+	}
+}
+
+// DownloadConfigs contains the configs related to downloading files from the api server.
+type DownloadConfigs struct {
+	/*Maximum size of a downloaded file in bytes. Downloads of bigger files fail with a FileTooLargeError. 0 means no limit.*/
+	MaxSize int64 `json:"max_size"`
+	/*If true, the number of downloaded bytes is checked against the size reported by getFile (if the size is reported).*/
+	VerifySize bool `json:"verify_size"`
+	/*Progress is called every time a part of a file is downloaded. "total" is the size reported by getFile and it's 0 if the size is unknown.
+	This field is not saved in the config file.*/
+	Progress func(fileId string, downloaded, total int64) `json:"-"`
+}
+
+// DispatcherConfigs contains the configs of the update dispatcher.
+type DispatcherConfigs struct {
+	/*Number of the workers which process the updates concurrently. Updates of the same chat are always processed by the same worker. Defaults to 4 times the number of CPUs.*/
+	Workers int `json:"workers"`
+	/*Number of the updates which can wait in the queue of each worker. When a queue is full, receiving new updates is paused until there is room in the queue. Defaults to 64.*/
+	QueueSize int `json:"queue_size"`
+}
+
+// DefaultDispatcherConfigs returns a dispatcher config with the default number of workers and queue size.
+func DefaultDispatcherConfigs() *DispatcherConfigs {
+	return &DispatcherConfigs{
+		Workers:   4 * runtime.NumCPU(),
+		QueueSize: 64,
+	}
 }
 
 // DefaultUpdateConfigs returns a default update configs.
@@ -0,0 +1,27 @@
+package telego_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+func TestDownloadBytesWithContext(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	fileId := srv.AddFile([]byte("content"))
+	data, err := bot.DownloadBytes(fileId, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "content" {
+		t.Fatal("wrong downloaded content :", string(data))
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := bot.WithContext(ctx).DownloadBytes(fileId, 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("expected the download to be cancelled, got %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package telego
+
+import (
+	"errors"
+	"strconv"
+
+	errs "github.com/SakoDroid/telego/v2/errors"
+)
+
+/*Maximum length of the stack trace sent by "ReportErrorTo". Telegram messages can't be longer than 4096 characters.*/
+const maxReportedStack = 3000
+
+/*
+LogError writes the given error in the logs of the bot. If the error is a *errors.PanicError, the stack trace is written too. It can be used as the "OnError" hook of the configs :
+
+	cfg.OnError = bot.LogError
+*/
+func (bot *Bot) LogError(err error) {
+	var pe *errs.PanicError
+	if errors.As(err, &pe) {
+		bot.logger.GetRaw().Println("Recovered from", pe.Error()+"\n"+string(pe.Stack))
+		return
+	}
+	bot.logger.GetRaw().Println(err)
+}
+
+/*
+ReportErrorTo returns a hook which logs the errors and sends them to the given chat (for example the chat of the admin of the bot). The update id and the stack trace of the panics are included in the message.
+It can be used as the "OnError" hook of the configs :
+
+	cfg.OnError = bot.ReportErrorTo(adminChatId)
+*/
+func (bot *Bot) ReportErrorTo(chatId int) func(err error) {
+	return func(err error) {
+		bot.LogError(err)
+		text := "⚠️ " + err.Error()
+		var pe *errs.PanicError
+		if errors.As(err, &pe) {
+			stack := string(pe.Stack)
+			if len(stack) > maxReportedStack {
+				stack = stack[:maxReportedStack] + "\n..."
+			}
+			text += "\n\n" + stack
+		}
+		if _, err2 := bot.SendMessage(chatId, text, "", 0, false, false, nil); err2 != nil {
+			bot.logger.GetRaw().Println("Unable to report the error to chat " + strconv.Itoa(chatId) + ". " + err2.Error())
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package telego_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+
+	telego "github.com/SakoDroid/telego/v2"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+func TestPanicIsReported(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	cfg := srv.Configs()
+	bot, err := telego.NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.OnError = bot.ReportErrorTo(99)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(func() {
+		cancel()
+		bot.Stop()
+	})
+	if err := bot.RunCtx(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	bot.AddHandler("/boom", func(u *objs.Update) {
+		panic("boom")
+	}, "private")
+	bot.AddHandler("/hi", func(u *objs.Update) {
+		bot.SendMessage(u.Message.Chat.Id, "hi", "", 0, false, false, nil)
+	}, "private")
+
+	update := srv.SendText(10, 10, "/boom")
+	report := srv.ExpectCall(t, "sendMessage", func(c *telegotest.Call) bool { return c.Param("chat_id") == "99" })
+	text := report.Param("text")
+	if !strings.Contains(text, "boom") || !strings.Contains(text, "Update id : "+strconv.Itoa(update.Update_id)) || !strings.Contains(text, "goroutine") {
+		t.Fatalf("the report doesn't contain the panic, update id and stack : %q", text)
+	}
+	//The bot keeps working after the panic.
+	srv.ExpectReply(t, srv.SendText(10, 10, "/hi"), "hi")
+}
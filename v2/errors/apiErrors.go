@@ -0,0 +1,138 @@
+package errors
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+These errors indicate the common failures returned by the api server. The errors returned by the api methods can be checked against them using "errors.Is" :
+
+	_, err := bot.SendMessage(chatId, "hi", "", 0, false, false)
+	if errors.Is(err, errs.ErrBotBlocked) {
+		//Remove the user from the mailing list.
+	}
+*/
+var (
+	ErrBotBlocked            = errors.New("bot was blocked by the user")
+	ErrChatNotFound          = errors.New("chat not found")
+	ErrMessageNotModified    = errors.New("message is not modified")
+	ErrMessageToEditNotFound = errors.New("message to edit not found")
+	ErrNotEnoughRights       = errors.New("not enough rights")
+	ErrTooManyRequests       = errors.New("too many requests")
+	ErrChatMigrated          = errors.New("chat has been migrated to a supergroup")
+	//ErrServerError matches the failures caused by the api server itself (5xx status codes). The request can be retried later.
+	ErrServerError = errors.New("api server error")
+	//ErrNetwork matches the requests which did not reach the api server or whose response was not received.
+	ErrNetwork = errors.New("network error")
+)
+
+// APIError is a failure returned by the api server. The raw failure result is kept in "FailureResult".
+type APIError struct {
+	FailureResult *objs.FailureResult
+	//kind is one of the ErrXxx variables of this package. It's nil if the failure is not a known one.
+	kind error
+}
+
+func (ae *APIError) Error() string {
+	return ae.FailureResult.Description
+}
+
+// Unwrap returns the ErrXxx variable which matches this failure. It returns nil if the failure is not a known one.
+func (ae *APIError) Unwrap() error {
+	return ae.kind
+}
+
+// TooManyRequestsError is returned when the api server responds with flood control error (429). It matches ErrTooManyRequests.
+type TooManyRequestsError struct {
+	*APIError
+	//RetryAfter is the duration which should be waited before sending the request again.
+	RetryAfter time.Duration
+}
+
+// Unwrap returns the underlying APIError.
+func (tmre *TooManyRequestsError) Unwrap() error {
+	return tmre.APIError
+}
+
+// ChatMigratedError is returned when the group has been upgraded to a supergroup. It matches ErrChatMigrated.
+type ChatMigratedError struct {
+	*APIError
+	//NewChatId is the id of the supergroup which the group has been migrated to.
+	NewChatId int
+}
+
+// Unwrap returns the underlying APIError.
+func (cme *ChatMigratedError) Unwrap() error {
+	return cme.APIError
+}
+
+// NetworkError is returned when the request could not be sent to the api server or its response could not be received. It matches ErrNetwork.
+type NetworkError struct {
+	Err error
+}
+
+func (ne *NetworkError) Error() string {
+	return ne.Err.Error()
+}
+
+// Unwrap returns the underlying error, for example a *url.Error.
+func (ne *NetworkError) Unwrap() error {
+	return ne.Err
+}
+
+// Is reports whether the target is ErrNetwork.
+func (ne *NetworkError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
+/*
+FromFailureResult classifies the given failure result and returns the matching error. The returned error is a *TooManyRequestsError, a *ChatMigratedError or an *APIError.
+Returns nil if the failure result is nil.
+*/
+func FromFailureResult(fr *objs.FailureResult) error {
+	if fr == nil {
+		return nil
+	}
+	ae := &APIError{FailureResult: fr, kind: classify(fr)}
+	if fr.Parameters != nil {
+		if fr.Parameters.MigrateToChatId != 0 {
+			ae.kind = ErrChatMigrated
+			return &ChatMigratedError{APIError: ae, NewChatId: fr.Parameters.MigrateToChatId}
+		}
+		if fr.Parameters.RetryAfter > 0 {
+			ae.kind = ErrTooManyRequests
+			return &TooManyRequestsError{APIError: ae, RetryAfter: time.Duration(fr.Parameters.RetryAfter) * time.Second}
+		}
+	}
+	if ae.kind == ErrTooManyRequests {
+		return &TooManyRequestsError{APIError: ae}
+	}
+	return ae
+}
+
+/*Finds the known failure based on the error code and the description. Telegram does not document the descriptions, so they are matched loosely.*/
+func classify(fr *objs.FailureResult) error {
+	desc := strings.ToLower(fr.Description)
+	switch {
+	case fr.ErrorCode == 429 || strings.Contains(desc, "too many requests"):
+		return ErrTooManyRequests
+	case strings.Contains(desc, "bot was blocked by the user"):
+		return ErrBotBlocked
+	case strings.Contains(desc, "chat not found"):
+		return ErrChatNotFound
+	case strings.Contains(desc, "message is not modified"):
+		return ErrMessageNotModified
+	case strings.Contains(desc, "message to edit not found"):
+		return ErrMessageToEditNotFound
+	case strings.Contains(desc, "not enough rights") || strings.Contains(desc, "have no rights"):
+		return ErrNotEnoughRights
+	case fr.ErrorCode >= 500:
+		return ErrServerError
+	default:
+		return nil
+	}
+}
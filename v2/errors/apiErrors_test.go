@@ -0,0 +1,71 @@
+package errors
+
+import (
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func TestFailureClassification(t *testing.T) {
+	tests := []struct {
+		fr   *objs.FailureResult
+		kind error
+	}{
+		{&objs.FailureResult{ErrorCode: 403, Description: "Forbidden: bot was blocked by the user"}, ErrBotBlocked},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: chat not found"}, ErrChatNotFound},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: message is not modified: specified new message content and reply markup are exactly the same"}, ErrMessageNotModified},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: message to edit not found"}, ErrMessageToEditNotFound},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: not enough rights to send text messages to the chat"}, ErrNotEnoughRights},
+		{&objs.FailureResult{ErrorCode: 429, Description: "Too Many Requests: retry after 5"}, ErrTooManyRequests},
+		{&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: group chat was upgraded to a supergroup chat", Parameters: &objs.ResponseParameters{MigrateToChatId: -100123}}, ErrChatMigrated},
+		{&objs.FailureResult{ErrorCode: 502, Description: "Bad Gateway"}, ErrServerError},
+	}
+	for _, test := range tests {
+		err := &MethodNotSentError{Method: "sendMessage", FailureResult: test.fr, Err: FromFailureResult(test.fr)}
+		if !errors.Is(err, test.kind) {
+			t.Error("failure \"", test.fr.Description, "\" does not match", test.kind)
+		}
+		var ae *APIError
+		if !errors.As(err, &ae) || ae.FailureResult != test.fr {
+			t.Error("failure result is not attached to the error for \"", test.fr.Description, "\"")
+		}
+	}
+	if FromFailureResult(&objs.FailureResult{ErrorCode: 400, Description: "Bad Request: unknown"}).(*APIError).Unwrap() != nil {
+		t.Error("unknown failures should not match any of the known errors")
+	}
+}
+
+func TestTypedAPIErrors(t *testing.T) {
+	var err error = &MethodNotSentError{Err: FromFailureResult(&objs.FailureResult{
+		ErrorCode: 429, Description: "Too Many Requests: retry after 5", Parameters: &objs.ResponseParameters{RetryAfter: 5},
+	})}
+	var tmre *TooManyRequestsError
+	if !errors.As(err, &tmre) || tmre.RetryAfter != 5*time.Second {
+		t.Error("expected TooManyRequestsError with 5 seconds retry duration")
+	}
+	err = &MethodNotSentError{Err: FromFailureResult(&objs.FailureResult{
+		ErrorCode: 400, Description: "Bad Request: group chat was upgraded to a supergroup chat", Parameters: &objs.ResponseParameters{MigrateToChatId: -100123},
+	})}
+	var cme *ChatMigratedError
+	if !errors.As(err, &cme) || cme.NewChatId != -100123 {
+		t.Error("expected ChatMigratedError with the new chat id")
+	}
+}
+
+func TestNetworkError(t *testing.T) {
+	cause := &net.OpError{Op: "dial", Err: errors.New("connection refused")}
+	var err error = &MethodNotSentError{Method: "getMe", Err: &NetworkError{Err: cause}}
+	if !errors.Is(err, ErrNetwork) {
+		t.Error("network error does not match ErrNetwork")
+	}
+	var opErr *net.OpError
+	if !errors.As(err, &opErr) || opErr != cause {
+		t.Error("the cause of the network error is not accessible")
+	}
+	if errors.Is(err, ErrServerError) {
+		t.Error("network error should not match ErrServerError")
+	}
+}
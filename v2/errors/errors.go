@@ -11,6 +11,10 @@ import (
 type MethodNotSentError struct {
 	Method, Reason string
 	FailureResult  *objs.FailureResult
+	//StatusCode is the http status code of the response. It is zero if no response has been received.
+	StatusCode int
+	//Err is the underlying error (if any) which caused the request to fail, for example a network error. If the api server has returned a failure result, Err is the *APIError (or one of the more specific api errors) built from it.
+	Err error
 }
 
 func (mnse *MethodNotSentError) Error() string {
@@ -21,7 +25,14 @@ func (mnse *MethodNotSentError) Error() string {
 	return out
 }
 
+// Unwrap returns the underlying error.
+func (mnse *MethodNotSentError) Unwrap() error {
+	return mnse.Err
+}
+
 // BotInterfaceAlreadyCreated indicates that the bai is already created.
+//
+// Deprecated: More than one bot interface can be created now and this error is no longer returned.
 type BotInterfaceAlreadyCreated struct {
 }
 
@@ -86,3 +97,49 @@ type MethodDeprecated struct {
 func (m *MethodDeprecated) Error() string {
 	return fmt.Sprintf("This method (%s) has been deprecated. Please use %s instead.", m.MethodName, m.Replacement)
 }
+
+// FileTooLargeError indicates that the file which is being downloaded is bigger than the maximum allowed size.
+type FileTooLargeError struct {
+	FileId  string
+	MaxSize int64
+}
+
+func (ftle *FileTooLargeError) Error() string {
+	return "the file " + ftle.FileId + " is larger than the maximum allowed size (" + strconv.FormatInt(ftle.MaxSize, 10) + " bytes)"
+}
+
+// FileSizeMismatchError indicates that the number of downloaded bytes is not equal to the size reported by the api server.
+type FileSizeMismatchError struct {
+	FileId           string
+	Expected, Actual int64
+}
+
+func (fsme *FileSizeMismatchError) Error() string {
+	return fmt.Sprintf("downloaded %d bytes of the file %s but the expected size was %d bytes", fsme.Actual, fsme.FileId, fsme.Expected)
+}
+
+// PanicError is passed to the "OnError" hook of the bot when a handler or a middleware panics. The panic is recovered and the bot keeps working.
+type PanicError struct {
+	//Source is "handler" or "middleware".
+	Source string
+	//Value is the value passed to panic.
+	Value any
+	//Stack is the stack trace of the goroutine which panicked.
+	Stack []byte
+	//Update is the update which was being processed.
+	Update *objs.Update
+}
+
+func (pe *PanicError) Error() string {
+	out := fmt.Sprintf("panic in %s : %v", pe.Source, pe.Value)
+	if pe.Update != nil {
+		out += ". Update id : " + strconv.Itoa(pe.Update.Update_id) + ", type : " + pe.Update.GetType()
+	}
+	return out
+}
+
+// Unwrap returns the panic value if it is an error.
+func (pe *PanicError) Unwrap() error {
+	err, _ := pe.Value.(error)
+	return err
+}
@@ -16,9 +16,6 @@ type BotLogger struct {
 // Logger is the default logger of the bot.
 // var Logger *log.Logger
 
-// colorized indicates if logs should be colored, default is true.
-var colorized = true
-
 const (
 	HEADER    string = "\033[95m"
 	OKBLUE    string = "\033[94m"
@@ -52,7 +49,7 @@ func InitTheLogger(botCfg *cfg.BotConfigs) *BotLogger {
 
 // Log logs the given paramteres based on the defined format.
 func (l *BotLogger) Log(header, space, content, after, headerColor, contentColor, afterColor string) {
-	if colorized {
+	if l.colorized {
 		text := "| " + headerColor + header + ENDC + space + contentColor + content + ENDC + " |" + afterColor + after + ENDC
 		l.logger.Println(text)
 	} else {
@@ -69,10 +66,10 @@ func (l *BotLogger) GetRaw() *log.Logger {
 
 // Uncolor, clears the colors of the logs.
 func (l *BotLogger) Uncolor() {
-	colorized = false
+	l.colorized = false
 }
 
 // Color adds color to the logs.
 func (l *BotLogger) Color() {
-	colorized = true
+	l.colorized = true
 }
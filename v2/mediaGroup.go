@@ -15,7 +15,7 @@ type MediaGroup struct {
 	allowSendingWihoutReply  bool
 	replyMarkup              objs.ReplyMarkup
 	media                    []objs.InputMedia
-	files                    []*os.File
+	files                    []*objs.InputFile
 }
 
 // PhotoInserter is a tool for inserting photos into the MediaGroup.
@@ -37,12 +37,19 @@ func (pi *PhotoInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (pi *PhotoInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
-		return err
+	if file == nil {
+		return errors.New("file is nil")
+	}
+	return pi.AddByInputFile(objs.FileFromOSFile(file))
+}
+
+/*AddByInputFile works like "AddByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (pi *PhotoInserter) AddByInputFile(file *objs.InputFile) error {
+	if file == nil {
+		return errors.New("file is nil")
 	}
 	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", "attach://"+stat.Name(), pi.caption, pi.parseMode, pi.captionEntities),
+		InputMediaDefault: fixTheDefault("photo", file.Value(), pi.caption, pi.parseMode, pi.captionEntities),
 		HasSpoiler:        pi.hasSpoiler,
 	}
 	pi.mg.media = append(pi.mg.media, im)
@@ -55,7 +62,7 @@ type VideoInserter struct {
 	mg                            *MediaGroup
 	caption, parseMode, thumb     string
 	captionEntities               []objs.MessageEntity
-	thumbFile                     *os.File
+	thumbFile                     *objs.InputFile
 	width, height, duration       int
 	supportsStreaming, hasSpoiler bool
 }
@@ -85,12 +92,19 @@ func (vi *VideoInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (vi *VideoInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
-		return err
+	if file == nil {
+		return errors.New("file is nil")
+	}
+	return vi.AddByInputFile(objs.FileFromOSFile(file))
+}
+
+/*AddByInputFile works like "AddByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (vi *VideoInserter) AddByInputFile(file *objs.InputFile) error {
+	if file == nil {
+		return errors.New("file is nil")
 	}
 	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", "attach://"+stat.Name(), vi.caption, vi.parseMode, vi.captionEntities),
+		InputMediaDefault: fixTheDefault("video", file.Value(), vi.caption, vi.parseMode, vi.captionEntities),
 		Thumb:             vi.thumb,
 		SupportsStreaming: vi.supportsStreaming,
 		HasSpoiler:        vi.hasSpoiler,
@@ -123,17 +137,32 @@ func (vi *VideoInserter) SetThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	vi.thumbFile = file
+	vi.thumbFile = objs.FileFromOSFile(file)
 	vi.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*SetThumbnailInputFile sets the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (vi *VideoInserter) SetThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		vi.thumbFile = nil
+		vi.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		vi.thumbFile = file
+	} else {
+		vi.thumbFile = nil
+	}
+	vi.thumb = file.Value()
+}
+
 // AnimationInserter is a tool for inserting animations into the MediaGroup.
 type AnimationInserter struct {
 	mg                        *MediaGroup
 	caption, parseMode, thumb string
 	captionEntities           []objs.MessageEntity
-	thumbFile                 *os.File
+	thumbFile                 *objs.InputFile
 	width, height, duration   int
 	hasSpoiler                bool
 }
@@ -162,12 +191,19 @@ func (ai *AnimationInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (ai *AnimationInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
-		return err
+	if file == nil {
+		return errors.New("file is nil")
+	}
+	return ai.AddByInputFile(objs.FileFromOSFile(file))
+}
+
+/*AddByInputFile works like "AddByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (ai *AnimationInserter) AddByInputFile(file *objs.InputFile) error {
+	if file == nil {
+		return errors.New("file is nil")
 	}
 	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("animation", file.Value(), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		HasSpoiler:        ai.hasSpoiler,
 	}
@@ -199,17 +235,50 @@ func (ai *AnimationInserter) SetThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	ai.thumbFile = file
+	ai.thumbFile = objs.FileFromOSFile(file)
 	ai.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*SetThumbnailInputFile sets the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (ai *AnimationInserter) SetThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		ai.thumbFile = nil
+		ai.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		ai.thumbFile = file
+	} else {
+		ai.thumbFile = nil
+	}
+	ai.thumb = file.Value()
+}
+
+/*
+EditThumbnail sets the thumbnail of the file.
+
+Deprecated: Use "SetThumbnail" instead. Thumbnails of the sent animations can be edited using "EditThumbnail" of AnimationEditor.
+*/
+func (ai *AnimationInserter) EditThumbnail(fileIdOrURL string) {
+	ai.SetThumbnail(fileIdOrURL)
+}
+
+/*
+EditThumbnailFile sets the thumbnail of the file.
+
+Deprecated: Use "SetThumbnailFile" instead. Thumbnails of the sent animations can be edited using "EditThumbnailFile" of AnimationEditor.
+*/
+func (ai *AnimationInserter) EditThumbnailFile(file *os.File) error {
+	return ai.SetThumbnailFile(file)
+}
+
 // AudioInserter is a tool for inserting audios into the MediaGroup.
 type AudioInserter struct {
 	mg                                          *MediaGroup
 	caption, parseMode, thumb, performer, title string
 	captionEntities                             []objs.MessageEntity
-	thumbFile                                   *os.File
+	thumbFile                                   *objs.InputFile
 	duration                                    int
 }
 
@@ -232,12 +301,19 @@ func (ai *AudioInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (ai *AudioInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
-		return err
+	if file == nil {
+		return errors.New("file is nil")
+	}
+	return ai.AddByInputFile(objs.FileFromOSFile(file))
+}
+
+/*AddByInputFile works like "AddByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (ai *AudioInserter) AddByInputFile(file *objs.InputFile) error {
+	if file == nil {
+		return errors.New("file is nil")
 	}
 	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("audio", file.Value(), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		Performer:         ai.performer,
 		Title:             ai.title,
@@ -264,17 +340,32 @@ func (ai *AudioInserter) SetThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	ai.thumbFile = file
+	ai.thumbFile = objs.FileFromOSFile(file)
 	ai.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*SetThumbnailInputFile sets the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (ai *AudioInserter) SetThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		ai.thumbFile = nil
+		ai.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		ai.thumbFile = file
+	} else {
+		ai.thumbFile = nil
+	}
+	ai.thumb = file.Value()
+}
+
 // DocumentInserter is a tool for inserting documents into the MediaGroup.
 type DocumentInserter struct {
 	mg                          *MediaGroup
 	caption, parseMode, thumb   string
 	captionEntities             []objs.MessageEntity
-	thumbFile                   *os.File
+	thumbFile                   *objs.InputFile
 	disableContentTypeDetection bool
 }
 
@@ -293,12 +384,19 @@ func (di *DocumentInserter) AddByFileIdOrURL(fileIdOrUrl string) {
 
 /*AddByFile adds an existing file in the device*/
 func (di *DocumentInserter) AddByFile(file *os.File) error {
-	stat, err := file.Stat()
-	if err != nil {
-		return err
+	if file == nil {
+		return errors.New("file is nil")
+	}
+	return di.AddByInputFile(objs.FileFromOSFile(file))
+}
+
+/*AddByInputFile works like "AddByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (di *DocumentInserter) AddByInputFile(file *objs.InputFile) error {
+	if file == nil {
+		return errors.New("file is nil")
 	}
 	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", "attach://"+stat.Name(), di.caption, di.parseMode, di.captionEntities),
+		InputMediaDefault:           fixTheDefault("document", file.Value(), di.caption, di.parseMode, di.captionEntities),
 		Thumb:                       di.thumb,
 		DisableContentTypeDetection: di.disableContentTypeDetection,
 	}
@@ -321,11 +419,26 @@ func (di *DocumentInserter) SetThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	di.thumbFile = file
+	di.thumbFile = objs.FileFromOSFile(file)
 	di.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*SetThumbnailInputFile sets the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (di *DocumentInserter) SetThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		di.thumbFile = nil
+		di.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		di.thumbFile = file
+	} else {
+		di.thumbFile = nil
+	}
+	di.thumb = file.Value()
+}
+
 /*
 Send sends this album (to all types of chat but channels, to send to channels use "SendToChannel" method)
 
@@ -32,7 +32,7 @@ type MediaSender struct {
 	replyMarkup                                                         objs.ReplyMarkup
 	duration, length, width, height                                     int
 	supportsStreaming, disableContentTypeDetection                      bool
-	thumbFile                                                           *os.File
+	thumbFile                                                           *objs.InputFile
 }
 
 /*SendByFileIdOrUrl sends a file that already exists on telegram servers (file id) or a url on the web.*/
@@ -91,54 +91,63 @@ func (ms *MediaSender) SendByFileIdOrUrl(fileIdOrUrl string, silent, protectCont
 
 /*SendByFile sends a file that is located in this device.*/
 func (ms *MediaSender) SendByFile(file *os.File, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
-	stat, err := file.Stat()
-	if err != nil {
-		return nil, err
+	if file == nil {
+		return nil, errors.New("file is nil")
+	}
+	return ms.Send(objs.FileFromOSFile(file), silent, protectContent)
+}
+
+/*
+Send sends the given InputFile. The file can be created from a reader, a byte slice, a file path, a file id or a url using "objects.FileFromReader", "objects.FileFromBytes", "objects.FileFromPath", "objects.FileFromID" and "objects.FileFromURL".
+*/
+func (ms *MediaSender) Send(file *objs.InputFile, silent, protectContent bool) (*objs.Result[*objs.Message], error) {
+	if file == nil {
+		return nil, errors.New("file is nil")
 	}
 	switch ms.mediaType {
 	case PHOTO:
 		return ms.bot.apiInterface.SendPhoto(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatIdInt, ms.username, file.Value(), file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler, ms.replyMarkup, ms.captionEntities,
 		)
 	case VIDEO:
 		return ms.bot.apiInterface.SendVideo(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(),
+			ms.chatIdInt, ms.username, file.Value(),
 			file, ms.caption, ms.parseMode, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler,
 			ms.captionEntities, ms.duration, ms.supportsStreaming, ms.replyMarkup,
 		)
 	case AUDIO:
 		return ms.bot.apiInterface.SendAudio(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatIdInt, ms.username, file.Value(), file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.captionEntities, ms.duration, ms.performer, ms.title, ms.replyMarkup,
 		)
 	case ANIMATION:
 		return ms.bot.apiInterface.SendAnimation(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatIdInt, ms.username, file.Value(), file, ms.caption, ms.parseMode,
 			ms.width, ms.height, ms.duration, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile,
 			silent, ms.allowSendingWihoutReply, protectContent, ms.hasSpoiler, ms.captionEntities, ms.replyMarkup,
 		)
 	case DOCUMENT:
 		return ms.bot.apiInterface.SendDocument(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatIdInt, ms.username, file.Value(), file, ms.caption, ms.parseMode,
 			ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities,
 			ms.disableContentTypeDetection, ms.replyMarkup,
 		)
 	case VIDEONOTE:
 		return ms.bot.apiInterface.SendVideoNote(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatIdInt, ms.username, file.Value(), file, ms.caption, ms.parseMode,
 			ms.length, ms.duration, ms.replyTo, ms.messageThreadId, ms.thumb, ms.thumbFile, silent,
 			ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case VOICE:
 		return ms.bot.apiInterface.SendVoice(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), file, ms.caption, ms.parseMode,
+			ms.chatIdInt, ms.username, file.Value(), file, ms.caption, ms.parseMode,
 			ms.duration, ms.replyTo, ms.messageThreadId, silent, ms.allowSendingWihoutReply, protectContent, ms.captionEntities, ms.replyMarkup,
 		)
 	case STICKER:
 		return ms.bot.apiInterface.SendSticker(
-			ms.chatIdInt, ms.username, "attach://"+stat.Name(), ms.stickerEmoji, silent, ms.allowSendingWihoutReply, protectContent,
+			ms.chatIdInt, ms.username, file.Value(), ms.stickerEmoji, silent, ms.allowSendingWihoutReply, protectContent,
 			ms.replyTo, ms.messageThreadId, ms.replyMarkup, file,
 		)
 	default:
@@ -163,7 +172,25 @@ func (ms *MediaSender) SetThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	ms.thumbFile = file
+	ms.thumbFile = objs.FileFromOSFile(file)
 	ms.thumb = "attach://" + stat.Name()
 	return nil
 }
+
+/*
+SetThumbnailInputFile sets the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.
+If this media does not support thumbnail, the thumbnail will be ignored.
+*/
+func (ms *MediaSender) SetThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		ms.thumbFile = nil
+		ms.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		ms.thumbFile = file
+	} else {
+		ms.thumbFile = nil
+	}
+	ms.thumb = file.Value()
+}
@@ -2,6 +2,7 @@ package telego
 
 import (
 	"encoding/json"
+	"errors"
 	"os"
 	"time"
 
@@ -34,12 +35,19 @@ func (pi *PhotoEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[json.
 
 /*EditByFile edits this photo with an existing file in the device*/
 func (pi *PhotoEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
-		return nil, err
+	if file == nil {
+		return nil, errors.New("file is nil")
+	}
+	return pi.EditByInputFile(objs.FileFromOSFile(file))
+}
+
+/*EditByInputFile works like "EditByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (pi *PhotoEditor) EditByInputFile(file *objs.InputFile) (*objs.Result[json.RawMessage], error) {
+	if file == nil {
+		return nil, errors.New("file is nil")
 	}
 	im := &objs.InputMediaPhoto{
-		InputMediaDefault: fixTheDefault("photo", "attach://"+stat.Name(), pi.caption, pi.parseMode, pi.captionEntities),
+		InputMediaDefault: fixTheDefault("photo", file.Value(), pi.caption, pi.parseMode, pi.captionEntities),
 	}
 	return pi.mg.editMedia(pi.messageId, pi.inlineMessageId, im, pi.replyMarkup, file)
 }
@@ -50,7 +58,7 @@ type VideoEditor struct {
 	messageId                                  int
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  *objs.InputFile
 	width, height, duration                    int
 	supportsStreaming                          bool
 	replyMarkup                                *objs.InlineKeyboardMarkup
@@ -77,12 +85,19 @@ func (vi *VideoEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[json.
 
 /*EditByFile edits this video by file in the device*/
 func (vi *VideoEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
-		return nil, err
+	if file == nil {
+		return nil, errors.New("file is nil")
+	}
+	return vi.EditByInputFile(objs.FileFromOSFile(file))
+}
+
+/*EditByInputFile works like "EditByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (vi *VideoEditor) EditByInputFile(file *objs.InputFile) (*objs.Result[json.RawMessage], error) {
+	if file == nil {
+		return nil, errors.New("file is nil")
 	}
 	im := &objs.InputMediaVideo{
-		InputMediaDefault: fixTheDefault("video", "attach://"+stat.Name(), vi.caption, vi.parseMode, vi.captionEntities),
+		InputMediaDefault: fixTheDefault("video", file.Value(), vi.caption, vi.parseMode, vi.captionEntities),
 		Thumb:             vi.thumb,
 		SupportsStreaming: vi.supportsStreaming,
 	}
@@ -109,18 +124,33 @@ func (vi *VideoEditor) EditThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	vi.thumbFile = file
+	vi.thumbFile = objs.FileFromOSFile(file)
 	vi.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*EditThumbnailInputFile edits the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (vi *VideoEditor) EditThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		vi.thumbFile = nil
+		vi.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		vi.thumbFile = file
+	} else {
+		vi.thumbFile = nil
+	}
+	vi.thumb = file.Value()
+}
+
 // AnimationEditor is a tool for editing animations.
 type AnimationEditor struct {
 	mg                                         *MessageEditor
 	messageId                                  int
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  *objs.InputFile
 	width, height, duration                    int
 	replyMarkup                                *objs.InlineKeyboardMarkup
 }
@@ -145,12 +175,19 @@ func (ai *AnimationEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[j
 
 /*EditByFile edits this animation by file in the device*/
 func (ai *AnimationEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
-		return nil, err
+	if file == nil {
+		return nil, errors.New("file is nil")
+	}
+	return ai.EditByInputFile(objs.FileFromOSFile(file))
+}
+
+/*EditByInputFile works like "EditByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (ai *AnimationEditor) EditByInputFile(file *objs.InputFile) (*objs.Result[json.RawMessage], error) {
+	if file == nil {
+		return nil, errors.New("file is nil")
 	}
 	im := &objs.InputMediaAnimation{
-		InputMediaDefault: fixTheDefault("animation", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("animation", file.Value(), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 	}
 	if ai.width != 0 {
@@ -166,28 +203,43 @@ func (ai *AnimationEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessa
 }
 
 /*EditThumbnail edits the tumbnail of the file. It takes a fileId or a url. If you want to send a file use "setThumbnailFile" instead.*/
-func (ai *AnimationInserter) EditThumbnail(fileIdOrURL string) {
+func (ai *AnimationEditor) EditThumbnail(fileIdOrURL string) {
 	ai.thumb = fileIdOrURL
 }
 
 /*EditThumbnailFile edits the thumbnail of the file. It takes a file existing on the device*/
-func (ai *AnimationInserter) EditThumbnailFile(file *os.File) error {
+func (ai *AnimationEditor) EditThumbnailFile(file *os.File) error {
 	stat, err := file.Stat()
 	if err != nil {
 		return err
 	}
-	ai.thumbFile = file
+	ai.thumbFile = objs.FileFromOSFile(file)
 	ai.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*EditThumbnailInputFile edits the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (ai *AnimationEditor) EditThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		ai.thumbFile = nil
+		ai.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		ai.thumbFile = file
+	} else {
+		ai.thumbFile = nil
+	}
+	ai.thumb = file.Value()
+}
+
 // AudioEditor is a tool for editing audios.
 type AudioEditor struct {
 	mg                                                           *MessageEditor
 	messageId                                                    int
 	inlineMessageId, caption, parseMode, thumb, performer, title string
 	captionEntities                                              []objs.MessageEntity
-	thumbFile                                                    *os.File
+	thumbFile                                                    *objs.InputFile
 	duration                                                     int
 	replyMarkup                                                  *objs.InlineKeyboardMarkup
 }
@@ -208,12 +260,19 @@ func (ai *AudioEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[json.
 
 /*EditByFile edits this audio by file in the device*/
 func (ai *AudioEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
-		return nil, err
+	if file == nil {
+		return nil, errors.New("file is nil")
+	}
+	return ai.EditByInputFile(objs.FileFromOSFile(file))
+}
+
+/*EditByInputFile works like "EditByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (ai *AudioEditor) EditByInputFile(file *objs.InputFile) (*objs.Result[json.RawMessage], error) {
+	if file == nil {
+		return nil, errors.New("file is nil")
 	}
 	im := &objs.InputMediaAudio{
-		InputMediaDefault: fixTheDefault("audio", "attach://"+stat.Name(), ai.caption, ai.parseMode, ai.captionEntities),
+		InputMediaDefault: fixTheDefault("audio", file.Value(), ai.caption, ai.parseMode, ai.captionEntities),
 		Thumb:             ai.thumb,
 		Performer:         ai.performer,
 		Title:             ai.title,
@@ -235,18 +294,33 @@ func (ai *AudioEditor) EditThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	ai.thumbFile = file
+	ai.thumbFile = objs.FileFromOSFile(file)
 	ai.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*EditThumbnailInputFile edits the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (ai *AudioEditor) EditThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		ai.thumbFile = nil
+		ai.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		ai.thumbFile = file
+	} else {
+		ai.thumbFile = nil
+	}
+	ai.thumb = file.Value()
+}
+
 // DocumentEditor is a tool for editing documents.
 type DocumentEditor struct {
 	mg                                         *MessageEditor
 	messageId                                  int
 	inlineMessageId, caption, parseMode, thumb string
 	captionEntities                            []objs.MessageEntity
-	thumbFile                                  *os.File
+	thumbFile                                  *objs.InputFile
 	disableContentTypeDetection                bool
 	replyMarkup                                *objs.InlineKeyboardMarkup
 }
@@ -263,12 +337,19 @@ func (di *DocumentEditor) EditByFileIdOrURL(fileIdOrUrl string) (*objs.Result[js
 
 /*EditByFile edits this document by file in the device*/
 func (di *DocumentEditor) EditByFile(file *os.File) (*objs.Result[json.RawMessage], error) {
-	stat, err := file.Stat()
-	if err != nil {
-		return nil, err
+	if file == nil {
+		return nil, errors.New("file is nil")
+	}
+	return di.EditByInputFile(objs.FileFromOSFile(file))
+}
+
+/*EditByInputFile works like "EditByFile" but takes an InputFile, so the file can be a reader, a byte slice, a file path, a file id or a url.*/
+func (di *DocumentEditor) EditByInputFile(file *objs.InputFile) (*objs.Result[json.RawMessage], error) {
+	if file == nil {
+		return nil, errors.New("file is nil")
 	}
 	im := &objs.InputMediaDocument{
-		InputMediaDefault:           fixTheDefault("document", "attach://"+stat.Name(), di.caption, di.parseMode, di.captionEntities),
+		InputMediaDefault:           fixTheDefault("document", file.Value(), di.caption, di.parseMode, di.captionEntities),
 		Thumb:                       di.thumb,
 		DisableContentTypeDetection: di.disableContentTypeDetection,
 	}
@@ -286,11 +367,26 @@ func (di *DocumentEditor) EditThumbnailFile(file *os.File) error {
 	if err != nil {
 		return err
 	}
-	di.thumbFile = file
+	di.thumbFile = objs.FileFromOSFile(file)
 	di.thumb = "attach://" + stat.Name()
 	return nil
 }
 
+/*EditThumbnailInputFile edits the thumbnail of the file. It takes an InputFile which can be created from a reader, a byte slice, a file path, a file id or a url. Passing nil removes the thumbnail.*/
+func (di *DocumentEditor) EditThumbnailInputFile(file *objs.InputFile) {
+	if file == nil {
+		di.thumbFile = nil
+		di.thumb = ""
+		return
+	}
+	if file.NeedsUpload() {
+		di.thumbFile = file
+	} else {
+		di.thumbFile = nil
+	}
+	di.thumb = file.Value()
+}
+
 /*EditText can be used to edit text and game messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.*/
 func (me *MessageEditor) EditText(messageId int, text, inlineMessageId, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, keyboard *InlineKeyboard) (*objs.Result[json.RawMessage], error) {
 	var replyMarkup objs.InlineKeyboardMarkup
@@ -408,7 +504,7 @@ func (me *MessageEditor) DeletIn(messageId int, delay time.Duration) {
 	}()
 }
 
-func (me *MessageEditor) editMedia(messageId int, inlineMessageId string, media objs.InputMedia, replyMarkup *objs.InlineKeyboardMarkup, file ...*os.File) (*objs.Result[json.RawMessage], error) {
+func (me *MessageEditor) editMedia(messageId int, inlineMessageId string, media objs.InputMedia, replyMarkup *objs.InlineKeyboardMarkup, file ...*objs.InputFile) (*objs.Result[json.RawMessage], error) {
 	return me.bot.apiInterface.EditMessageMedia(
 		me.chatIdInt, me.chatIdString, messageId, inlineMessageId, media,
 		replyMarkup, file...,
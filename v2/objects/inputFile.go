@@ -0,0 +1,166 @@
+package objects
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+/*
+InputFile represents a file that is sent to the api server. It can be a new file that should be uploaded (a reader, a byte slice or a file on the device) or a file that already exists on the web or on telegram servers (a url or a file id).
+
+Not related to telegram bot api.
+*/
+type InputFile struct {
+	name        string
+	fileIdOrUrl string
+	path        string
+	data        []byte
+	reader      io.Reader
+	file        *os.File
+	offset      int64
+	opened      bool
+}
+
+// FileFromID returns an InputFile for a file that already exists on telegram servers.
+func FileFromID(fileId string) *InputFile {
+	return &InputFile{fileIdOrUrl: fileId}
+}
+
+// FileFromURL returns an InputFile for a file on the web. Telegram will download the file itself.
+func FileFromURL(url string) *InputFile {
+	return &InputFile{fileIdOrUrl: url}
+}
+
+// FileFromPath returns an InputFile for a file located on this device. The file is opened when the request is being sent and it's closed after that.
+func FileFromPath(path string) *InputFile {
+	return &InputFile{name: filepath.Base(path), path: path}
+}
+
+/*
+FileFromServerPath returns an InputFile for a file located on the machine which runs the local bot api server. The file is passed to the server as a "file://" uri and it's not uploaded.
+
+This only works when the bot is connected to a local bot api server which has been started with "--local" option.
+*/
+func FileFromServerPath(path string) *InputFile {
+	return &InputFile{fileIdOrUrl: "file://" + filepath.ToSlash(path)}
+}
+
+// FileFromBytes returns an InputFile which uploads the given bytes with the given file name.
+func FileFromBytes(name string, data []byte) *InputFile {
+	return &InputFile{name: name, data: data}
+}
+
+/*
+FileFromReader returns an InputFile which uploads the content of the given reader with the given file name.
+
+A reader can only be read once, so if the request should be sent again (for example when it is retried) the reader should implement io.Seeker, otherwise the request will not be sent again.
+*/
+func FileFromReader(name string, reader io.Reader) *InputFile {
+	return &InputFile{name: name, reader: reader}
+}
+
+// FileFromOSFile returns an InputFile for an already opened file. The file is not closed after the request is sent.
+func FileFromOSFile(file *os.File) *InputFile {
+	if file == nil {
+		return nil
+	}
+	return &InputFile{name: filepath.Base(file.Name()), file: file}
+}
+
+// NeedsUpload returns true if this file should be uploaded using multipart/form-data. Files created by id or url don't need to be uploaded.
+func (f *InputFile) NeedsUpload() bool {
+	return f.fileIdOrUrl == ""
+}
+
+// Name returns the name of this file. This name is used as the attach name of the file in multipart forms. It is empty for file ids and urls.
+func (f *InputFile) Name() string {
+	return f.name
+}
+
+// Value returns the value that should be passed to the api server for this file. It is "attach://<name>" for the files that are uploaded and the file id or url for other files.
+func (f *InputFile) Value() string {
+	if f.NeedsUpload() {
+		return "attach://" + f.name
+	}
+	return f.fileIdOrUrl
+}
+
+// Reusable reports whether the content of this file can be read more than once, so the request containing this file can be sent again.
+func (f *InputFile) Reusable() bool {
+	if !f.opened || f.data != nil || f.path != "" || f.file != nil {
+		return true
+	}
+	_, ok := f.reader.(io.Seeker)
+	return ok
+}
+
+/*
+Open opens the file for uploading and returns a reader to read it's content along with it's size. The size is -1 if it is not known. The returned reader should be closed after it's been read.
+
+Each call to this method returns the content from the beginning.
+*/
+func (f *InputFile) Open() (io.ReadCloser, int64, error) {
+	if !f.NeedsUpload() {
+		return nil, 0, errors.New("file ids and urls can not be opened")
+	}
+	first := !f.opened
+	f.opened = true
+	switch {
+	case f.data != nil:
+		return io.NopCloser(bytes.NewReader(f.data)), int64(len(f.data)), nil
+	case f.path != "":
+		file, err := os.Open(f.path)
+		if err != nil {
+			return nil, 0, err
+		}
+		return file, getRemainingSize(file), nil
+	case f.file != nil:
+		if err := f.rewind(f.file, first); err != nil {
+			return nil, 0, err
+		}
+		return io.NopCloser(f.file), getRemainingSize(f.file), nil
+	case f.reader != nil:
+		if err := f.rewind(f.reader, first); err != nil {
+			return nil, 0, err
+		}
+		size := int64(-1)
+		if lr, ok := f.reader.(interface{ Len() int }); ok {
+			size = int64(lr.Len())
+		}
+		return io.NopCloser(f.reader), size, nil
+	default:
+		return nil, 0, errors.New("input file has no content")
+	}
+}
+
+/*On the first read, the current offset is saved. On the next reads the reader is moved back to the saved offset.*/
+func (f *InputFile) rewind(reader io.Reader, first bool) error {
+	seeker, ok := reader.(io.Seeker)
+	if first {
+		if ok {
+			f.offset, _ = seeker.Seek(0, io.SeekCurrent)
+		}
+		return nil
+	}
+	if !ok {
+		return errors.New("the reader of the file " + f.name + " can not be read again")
+	}
+	_, err := seeker.Seek(f.offset, io.SeekStart)
+	return err
+}
+
+/*Returns the size of the file from the current offset to the end. Returns -1 if it is not known.*/
+func getRemainingSize(file *os.File) int64 {
+	stat, err := file.Stat()
+	if err != nil || !stat.Mode().IsRegular() {
+		return -1
+	}
+	offset, err := file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return -1
+	}
+	return stat.Size() - offset
+}
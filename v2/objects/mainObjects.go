@@ -1,5 +1,7 @@
 package objects
 
+import "encoding/json"
+
 /*
 This object represents an incoming update.
 At most one of the optional parameters can be present in any given update.
@@ -35,6 +37,20 @@ type Update struct {
 	ChatMember *ChatMemberUpdated `json:"chat_member,omitempty"`
 	/*Optional. A request to join the chat has been sent. The bot must have the can_invite_users administrator right in the chat to receive these updates.*/
 	ChatJoinRequest *ChatJoinRequest `json:"chat_join_request,omitempty"`
+	raw             json.RawMessage
+}
+
+/*
+Raw returns the json of this update exactly as it was received from the api server.
+Returns nil if the raw json has not been kept, which is the case unless "KeepRawUpdates" field of the bot configs is true.
+*/
+func (u *Update) Raw() json.RawMessage {
+	return u.raw
+}
+
+/*SetRaw sets the json returned by "Raw" method. It's used by the bot when "KeepRawUpdates" is enabled and by the replayer of the recorded updates.*/
+func (u *Update) SetRaw(raw json.RawMessage) {
+	u.raw = raw
 }
 
 /*Returnes the populated field of this update*/
@@ -307,3 +323,13 @@ type ChatUpdate struct {
 	ChatId string
 	Update *Update
 }
+
+/*Not related to telegram bot api. Command is a bot command parsed from a message.*/
+type Command struct {
+	/*Name of the command in lower case, without the "/" prefix and the "@botusername" suffix.*/
+	Name string
+	/*The arguments of the command, split shell style. Quoted arguments can contain spaces.*/
+	Args []string
+	/*The text after the command, as it was sent.*/
+	RawArgs string
+}
@@ -122,6 +122,16 @@ type DefaultSendMethodsArguments struct {
 	MessageThreadId int `json:"message_thread_id,omitempty"`
 }
 
+// GetChatId returns the target chat of this method.
+func (df *DefaultSendMethodsArguments) GetChatId() json.RawMessage {
+	return df.ChatId
+}
+
+// SetChatId changes the target chat of this method. It is used for resending a request to a migrated chat.
+func (df *DefaultSendMethodsArguments) SetChatId(chatId int) {
+	df.ChatId, _ = json.Marshal(chatId)
+}
+
 // ToMultiPart converts this strcut into HTTP multipart form to be sent to the API server.
 func (df *DefaultSendMethodsArguments) toMultiPart(wr *mp.Writer) {
 	fw, _ := wr.CreateFormField("chat_id")
@@ -0,0 +1,140 @@
+package telego_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	telego "github.com/SakoDroid/telego/v2"
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+/*Calls cond until it returns true. The test fails if it doesn't return true before the timeout of the server.*/
+func waitFor(t *testing.T, srv *telegotest.Server, cond func() bool, msg string) {
+	t.Helper()
+	deadline := time.Now().Add(srv.Timeout)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal(msg)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestAtLeastOnceOffset(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	offsetFile := filepath.Join(t.TempDir(), "offset")
+	var mx sync.Mutex
+	received := make(map[int]int)
+	release := make(chan struct{})
+	var bots []*telego.Bot
+	//The handlers are released and the bots are shut down before the offset file is removed.
+	t.Cleanup(func() {
+		close(release)
+		for _, bot := range bots {
+			bot.Shutdown(context.Background())
+		}
+	})
+	start := func() context.CancelFunc {
+		cfg := srv.Configs()
+		cfg.UpdateConfigs.OffsetFile = offsetFile
+		cfg.UpdateConfigs.AtLeastOnce = true
+		bot, err := telego.NewBot(cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bot.AddHandler(".*", func(u *objs.Update) {
+			mx.Lock()
+			received[u.Update_id]++
+			mx.Unlock()
+			if u.Message.Text == "/slow" {
+				<-release
+			}
+		}, "all")
+		ctx, cancel := context.WithCancel(context.Background())
+		if err := bot.RunCtx(ctx, false); err != nil {
+			t.Fatal(err)
+		}
+		bots = append(bots, bot)
+		return cancel
+	}
+	savedOffset := func() string {
+		bt, _ := os.ReadFile(offsetFile)
+		return string(bt)
+	}
+	count := func(id int) int {
+		mx.Lock()
+		defer mx.Unlock()
+		return received[id]
+	}
+
+	stop := start()
+	first, slow, last := srv.SendText(10, 10, "/a"), srv.SendText(11, 11, "/slow"), srv.SendText(12, 12, "/b")
+	waitFor(t, srv, func() bool { return count(last.Update_id) > 0 }, "the updates were not received")
+	waitFor(t, srv, func() bool { return savedOffset() == strconv.Itoa(first.Update_id) }, "the offset was not saved")
+	//The updates which are not confirmed are received again, but they are not processed twice.
+	time.Sleep(100 * time.Millisecond)
+	for _, u := range []*objs.Update{first, slow, last} {
+		if c := count(u.Update_id); c != 1 {
+			t.Fatalf("update %d was processed %d times", u.Update_id, c)
+		}
+	}
+	if got := savedOffset(); got != strconv.Itoa(first.Update_id) {
+		t.Fatalf("the offset was advanced before the update was processed : %s", got)
+	}
+	if pending := srv.PendingUpdates(); pending != 2 {
+		t.Fatalf("expected the updates from \"/slow\" to be unconfirmed, %d updates are pending", pending)
+	}
+
+	//Restarting the bot while "/slow" is being processed.
+	stop()
+	stop = start()
+	defer stop()
+	waitFor(t, srv, func() bool { return count(last.Update_id) == 2 }, "the unprocessed updates were not received after restarting")
+	if count(first.Update_id) != 1 || count(slow.Update_id) != 2 {
+		t.Fatalf("unexpected deliveries after restarting : %v", received)
+	}
+}
+
+func TestAtLeastOnceBlockedHandler(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	//No offset store is used : the unfinished updates are kept by the api server.
+	bot := startBot(t, srv, func(cfg *configs.BotConfigs) {
+		cfg.UpdateConfigs.AtLeastOnce = true
+	})
+	release := make(chan struct{})
+	var once sync.Once
+	releaseHandler := func() { once.Do(func() { close(release) }) }
+	t.Cleanup(func() {
+		releaseHandler()
+		bot.Shutdown(context.Background())
+	})
+	bot.AddHandler("/slow", func(u *objs.Update) {
+		<-release
+	}, "all")
+	bot.AddHandler("hi", func(u *objs.Update) {
+		bot.SendMessage(u.Message.Chat.Id, "hello", "", 0, false, false, nil)
+	}, "all")
+
+	srv.SendText(10, 10, "/slow")
+	for chat := 11; chat <= 14; chat++ {
+		srv.SendText(chat, chat, "hi")
+	}
+	waitFor(t, srv, func() bool { return len(srv.Calls("sendMessage")) == 4 }, "the updates after the blocked one were not processed")
+	//None of the updates are confirmed while the oldest one is being processed, and the updates which are received again are not processed twice.
+	time.Sleep(100 * time.Millisecond)
+	if pending := srv.PendingUpdates(); pending != 5 {
+		t.Fatalf("updates were confirmed before the blocked handler returned, %d updates are pending", pending)
+	}
+	if calls := len(srv.Calls("sendMessage")); calls != 4 {
+		t.Fatalf("the updates were processed %d times", calls)
+	}
+	releaseHandler()
+	waitFor(t, srv, func() bool { return srv.PendingUpdates() == 0 }, "the updates were not confirmed after the handler returned")
+}
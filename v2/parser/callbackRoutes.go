@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*callbackRoute routes the callback queries which their data matches a pattern. The named groups of the regex are passed to the handler as parameters.*/
+type callbackRoute struct {
+	regex    *regexp.Regexp
+	function func(*objs.Update, map[string]string)
+}
+
+/*callbackRouter keeps the callback routes in the order they were added.*/
+type callbackRouter struct {
+	mx     sync.RWMutex
+	routes []*callbackRoute
+	answer func(callbackQueryId string)
+}
+
+/*Matches the parameters of the templates, for example "{id}".*/
+var templateParamRegex = regexp.MustCompile(`\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+/*AddCallbackPrefixHandler adds a handler for the callback queries which their data starts with the given prefix. The handler receives no parameters.*/
+func (up *UpdateParser) AddCallbackPrefixHandler(prefix string, handlerFunc func(*objs.Update, map[string]string)) {
+	up.addCallbackRoute(regexp.MustCompile("^"+regexp.QuoteMeta(prefix)), handlerFunc)
+}
+
+/*AddCallbackRegexHandler adds a handler for the callback queries which their data matches the given regex. The named groups of the regex are passed to the handler as parameters.*/
+func (up *UpdateParser) AddCallbackRegexHandler(pattern string, handlerFunc func(*objs.Update, map[string]string)) error {
+	rgxp, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	up.addCallbackRoute(rgxp, handlerFunc)
+	return nil
+}
+
+/*
+AddCallbackTemplateHandler adds a handler for the callback queries which their data matches the given template. A template is a text containing parameters in "{name}" format, for example "item:{id}:{action}".
+Each parameter matches one or more characters until the character which comes after it in the template, and the last parameter matches the rest of the data. The values are passed to the handler as parameters.
+*/
+func (up *UpdateParser) AddCallbackTemplateHandler(template string, handlerFunc func(*objs.Update, map[string]string)) error {
+	rgxp, err := compileTemplate(template)
+	if err != nil {
+		return err
+	}
+	up.addCallbackRoute(rgxp, handlerFunc)
+	return nil
+}
+
+/*SetCallbackAnswerer sets the function which answers the callback queries that no handler matches. The callback queries are answered only if "AnswerUnhandledCallbacks" is true in the configs.*/
+func (up *UpdateParser) SetCallbackAnswerer(answer func(callbackQueryId string)) {
+	up.callbackRoutes.mx.Lock()
+	up.callbackRoutes.answer = answer
+	up.callbackRoutes.mx.Unlock()
+}
+
+func (up *UpdateParser) addCallbackRoute(rgxp *regexp.Regexp, handlerFunc func(*objs.Update, map[string]string)) {
+	cr := up.callbackRoutes
+	cr.mx.Lock()
+	cr.routes = append(cr.routes, &callbackRoute{regex: rgxp, function: handlerFunc})
+	cr.mx.Unlock()
+}
+
+/*Converts the template to a regex which has a named group for each parameter.*/
+func compileTemplate(template string) (*regexp.Regexp, error) {
+	locs := templateParamRegex.FindAllStringSubmatchIndex(template, -1)
+	if len(locs) == 0 {
+		return nil, errors.New("template has no parameters : " + template)
+	}
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	seen := make(map[string]bool)
+	for i, loc := range locs {
+		name := template[loc[2]:loc[3]]
+		if seen[name] {
+			return nil, errors.New("parameter " + name + " is repeated in template : " + template)
+		}
+		seen[name] = true
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		//The parameter ends at the next character of the template.
+		if loc[1] < len(template) && (i == len(locs)-1 || locs[i+1][0] != loc[1]) {
+			next, _ := utf8.DecodeRuneInString(template[loc[1]:])
+			pattern.WriteString("(?P<" + name + ">[^" + regexp.QuoteMeta(string(next)) + "]+)")
+		} else {
+			pattern.WriteString("(?P<" + name + ">.+)")
+		}
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]) + "$")
+	return regexp.Compile(pattern.String())
+}
+
+func (up *UpdateParser) checkCallbackRoutes(update *objs.Update) bool {
+	cr := up.callbackRoutes
+	data := update.CallbackQuery.Data
+	cr.mx.RLock()
+	routes, answer := cr.routes, cr.answer
+	cr.mx.RUnlock()
+	for _, route := range routes {
+		match := route.regex.FindStringSubmatch(data)
+		if match == nil {
+			continue
+		}
+		params := make(map[string]string)
+		for i, name := range route.regex.SubexpNames() {
+			if name != "" {
+				params[name] = match[i]
+			}
+		}
+		function := route.function
+		up.runHandler(func(u *objs.Update) { function(u, params) }, update)
+		return true
+	}
+	//The query is answered, so the button of the user doesn't keep loading.
+	if answer != nil && up.cfg.AnswerUnhandledCallbacks {
+		id := update.CallbackQuery.Id
+		up.runHandler(func(*objs.Update) { answer(id) }, update)
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package parser
+
+import (
+	"testing"
+)
+
+func TestCompileTemplate(t *testing.T) {
+	tests := []struct {
+		template, data string
+		expected       map[string]string
+	}{
+		{"item:{id}:{action}", "item:42:delete", map[string]string{"id": "42", "action": "delete"}},
+		{"item:{id}:{action}", "item:42:delete:now", map[string]string{"id": "42", "action": "delete:now"}},
+		{"item:{id}:{action}", "item::delete", nil},
+		{"item:{id}:{action}", "items:42:delete", nil},
+		{"user.{name}/edit", "user.john doe/edit", map[string]string{"name": "john doe"}},
+		{"user.{name}/edit", "userXjohn/edit", nil},
+	}
+	for _, test := range tests {
+		rgxp, err := compileTemplate(test.template)
+		if err != nil {
+			t.Fatal(err)
+		}
+		match := rgxp.FindStringSubmatch(test.data)
+		if (match == nil) != (test.expected == nil) {
+			t.Errorf("template %q matching %q : got %v", test.template, test.data, match)
+		}
+		if match == nil || test.expected == nil {
+			continue
+		}
+		for i, name := range rgxp.SubexpNames() {
+			if name != "" && match[i] != test.expected[name] {
+				t.Errorf("template %q matching %q : parameter %s is %q, expected %q", test.template, test.data, name, match[i], test.expected[name])
+			}
+		}
+	}
+	for _, template := range []string{"item", "{id}:{id}"} {
+		if _, err := compileTemplate(template); err == nil {
+			t.Errorf("template %q should be rejected", template)
+		}
+	}
+}
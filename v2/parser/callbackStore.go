@@ -0,0 +1,89 @@
+package parser
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"sync"
+	"time"
+
+	"github.com/SakoDroid/telego/v2/configs"
+)
+
+/*The default duration the stored callback data is kept.*/
+const defaultCallbackDataTTL = 24 * time.Hour
+
+/*The minimum duration between removing the expired callback data.*/
+const callbackStoreSweepInterval = time.Minute
+
+type storedCallbackData struct {
+	data    string
+	expires time.Time
+}
+
+/*callbackStore is the in memory CallbackDataStore which is used when no store is set in the configs.*/
+type callbackStore struct {
+	mx        sync.Mutex
+	entries   map[string]*storedCallbackData
+	lastSweep time.Time
+}
+
+/*Save keeps the data until the ttl passes. The expired data is removed at most once per "callbackStoreSweepInterval".*/
+func (cs *callbackStore) Save(key, data string, ttl time.Duration) error {
+	cs.mx.Lock()
+	defer cs.mx.Unlock()
+	now := time.Now()
+	if now.Sub(cs.lastSweep) > callbackStoreSweepInterval {
+		for k, entry := range cs.entries {
+			if now.After(entry.expires) {
+				delete(cs.entries, k)
+			}
+		}
+		cs.lastSweep = now
+	}
+	cs.entries[key] = &storedCallbackData{data: data, expires: now.Add(ttl)}
+	return nil
+}
+
+/*Load returns the data saved under the given key, if it has not expired.*/
+func (cs *callbackStore) Load(key string) (string, bool, error) {
+	cs.mx.Lock()
+	defer cs.mx.Unlock()
+	entry, ok := cs.entries[key]
+	if !ok || time.Now().After(entry.expires) {
+		return "", false, nil
+	}
+	return entry.data, true, nil
+}
+
+/*Returns the store which is set in the configs, or the in memory store if there is none.*/
+func (up *UpdateParser) getCallbackDataStore() configs.CallbackDataStore {
+	if up.cfg.CallbackDataStore != nil {
+		return up.cfg.CallbackDataStore
+	}
+	return up.callbackStore
+}
+
+/*
+StoreCallbackData keeps the given data and returns a short key which can be used as the callback data of a button instead. The data is removed after "CallbackDataTTL" of the configs.
+The data is kept in "CallbackDataStore" of the configs, or in memory if it's nil (in this case it doesn't survive restarting the bot).
+*/
+func (up *UpdateParser) StoreCallbackData(data string) (string, error) {
+	bt := make([]byte, 6)
+	if _, err := rand.Read(bt); err != nil {
+		return "", err
+	}
+	key := base64.RawURLEncoding.EncodeToString(bt)
+	ttl := up.cfg.CallbackDataTTL
+	if ttl <= 0 {
+		ttl = defaultCallbackDataTTL
+	}
+	if err := up.getCallbackDataStore().Save(key, data, ttl); err != nil {
+		return "", err
+	}
+	return key, nil
+}
+
+/*LoadCallbackData returns the data stored under the given key. Returns false if the key doesn't exist or the data has expired.*/
+func (up *UpdateParser) LoadCallbackData(key string) (string, bool, error) {
+	return up.getCallbackDataStore().Load(key)
+}
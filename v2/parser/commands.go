@@ -0,0 +1,194 @@
+package parser
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+type commandHandler struct {
+	name        string
+	description string
+	chatType    string                            //The chat types this handler will act on. Empty for all chats.
+	function    func(*objs.Update, *objs.Command) //The function to be executed
+}
+
+/*commandRouter keeps the command handlers and the username of the bot, which is used for checking the commands sent in groups.*/
+type commandRouter struct {
+	mx       sync.Mutex
+	handlers []*commandHandler
+	username string
+	resolve  func() (string, error)
+	//resolveMx serializes the calls to "resolve". It's never held while mx is locked, so the handlers can be checked while the username is being resolved.
+	resolveMx sync.Mutex
+	//After "resolve" fails, it's not called again before retryAt. The delay is doubled after each failure.
+	retryAt    time.Time
+	retryDelay time.Duration
+}
+
+const (
+	minUsernameRetryDelay = time.Second
+	maxUsernameRetryDelay = time.Minute
+)
+
+var errUsernameUnavailable = errors.New("the username of the bot is not available, resolving it has failed recently")
+
+/*
+AddCommandHandler adds a handler for the given command. The name is case insensitive and should not contain the "/" prefix. If a handler has already been added for the command, it is replaced.
+If no chat type is given, the handler acts on all chats.
+*/
+func (up *UpdateParser) AddCommandHandler(name, description string, handlerFunc func(*objs.Update, *objs.Command), chatTypes ...string) {
+	hl := &commandHandler{name: strings.ToLower(name), description: description, chatType: strings.Join(chatTypes, ","), function: handlerFunc}
+	cr := up.commands
+	cr.mx.Lock()
+	defer cr.mx.Unlock()
+	for i, old := range cr.handlers {
+		if old.name == hl.name {
+			cr.handlers[i] = hl
+			return
+		}
+	}
+	cr.handlers = append(cr.handlers, hl)
+}
+
+/*Commands returns the commands which have a handler and a description, in the order they were added.*/
+func (up *UpdateParser) Commands() []objs.BotCommand {
+	cr := up.commands
+	cr.mx.Lock()
+	defer cr.mx.Unlock()
+	out := make([]objs.BotCommand, 0, len(cr.handlers))
+	for _, hl := range cr.handlers {
+		if hl.description != "" {
+			out = append(out, objs.BotCommand{Command: hl.name, Description: hl.description})
+		}
+	}
+	return out
+}
+
+/*
+SetUsernameResolver sets the function which returns the username of the bot. It is called the first time a command with "@botusername" suffix is received in a group, and it's result is kept.
+If it returns an error, it's not called again for a while (starting from one second and doubled after each failure, up to one minute) and the commands which need the username are ignored meanwhile.
+*/
+func (up *UpdateParser) SetUsernameResolver(resolve func() (string, error)) {
+	up.commands.mx.Lock()
+	up.commands.resolve = resolve
+	up.commands.mx.Unlock()
+}
+
+/*Returns the username of the bot, calling the resolver if it's not known yet. The resolver is called without holding the lock of the handlers.*/
+func (cr *commandRouter) getUsername() (string, error) {
+	cr.resolveMx.Lock()
+	defer cr.resolveMx.Unlock()
+	cr.mx.Lock()
+	username, resolve, retryAt := cr.username, cr.resolve, cr.retryAt
+	cr.mx.Unlock()
+	if username != "" || resolve == nil {
+		return username, nil
+	}
+	if time.Now().Before(retryAt) {
+		return "", errUsernameUnavailable
+	}
+	username, err := resolve()
+	cr.mx.Lock()
+	defer cr.mx.Unlock()
+	if err != nil {
+		if cr.retryDelay < minUsernameRetryDelay {
+			cr.retryDelay = minUsernameRetryDelay
+		} else if cr.retryDelay *= 2; cr.retryDelay > maxUsernameRetryDelay {
+			cr.retryDelay = maxUsernameRetryDelay
+		}
+		cr.retryAt = time.Now().Add(cr.retryDelay)
+		return "", err
+	}
+	cr.username, cr.retryDelay = username, 0
+	return username, nil
+}
+
+func (cr *commandRouter) getHandler(name, chatType string) *commandHandler {
+	cr.mx.Lock()
+	defer cr.mx.Unlock()
+	for _, hl := range cr.handlers {
+		if hl.name == name && matchesChatType(hl.chatType, chatType) {
+			return hl
+		}
+	}
+	return nil
+}
+
+func (up *UpdateParser) checkCommandHandlers(update *objs.Update) bool {
+	msg := update.Message
+	text, entities := msg.Text, msg.Entities
+	if text == "" {
+		text, entities = msg.Caption, msg.CaptionEntities
+	}
+	//Only the commands at the beginning of the message are routed.
+	if len(entities) == 0 || entities[0].Type != "bot_command" || entities[0].Offset != 0 || entities[0].Length < 1 || entities[0].Length > len(text) {
+		return false
+	}
+	name, target, _ := strings.Cut(text[1:entities[0].Length], "@")
+	chatType := ""
+	if msg.Chat != nil {
+		chatType = msg.Chat.Type
+	}
+	//Commands of other bots can only be received in groups.
+	if target != "" && chatType != "private" {
+		username, err := up.commands.getUsername()
+		if err != nil {
+			up.logger.GetRaw().Println("Unable to get the username of the bot for checking the command.", err)
+			return false
+		}
+		if !strings.EqualFold(target, username) {
+			return false
+		}
+	}
+	hl := up.commands.getHandler(strings.ToLower(name), chatType)
+	if hl == nil {
+		return false
+	}
+	raw := strings.TrimLeftFunc(text[entities[0].Length:], unicode.IsSpace)
+	cmd := &objs.Command{Name: hl.name, Args: splitArgs(raw), RawArgs: raw}
+	up.runHandler(func(u *objs.Update) { hl.function(u, cmd) }, update)
+	return true
+}
+
+/*Splits the arguments like a shell. Single and double quotes group the words and backslash escapes the next character (except inside single quotes).*/
+func splitArgs(text string) []string {
+	args := []string{}
+	var current strings.Builder
+	inArg, escaped := false, false
+	var quote rune
+	for _, r := range text {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped, inArg = true, true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case r == '"' || r == '\'':
+			quote, inArg = r, true
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args
+}
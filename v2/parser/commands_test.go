@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func TestSplitArgs(t *testing.T) {
+	tests := map[string][]string{
+		"":                            {},
+		"  123   spam ":               {"123", "spam"},
+		`123 "too much spam"`:         {"123", "too much spam"},
+		`'a "quoted" arg' b`:          {`a "quoted" arg`, "b"},
+		`one\ arg "escaped \" quote"`: {"one arg", `escaped " quote`},
+		`empty "" arg`:                {"empty", "", "arg"},
+		`'single \ backslash'`:        {`single \ backslash`},
+	}
+	for text, expected := range tests {
+		if args := splitArgs(text); !reflect.DeepEqual(args, expected) {
+			t.Errorf("splitArgs(%q) = %q, expected %q", text, args, expected)
+		}
+	}
+}
+
+func TestUsernameResolver(t *testing.T) {
+	cfg := configs.Default("token")
+	uc := make(chan *objs.Update, 10)
+	cu := make(chan *objs.ChatUpdate, 10)
+	up := CreateUpdateParser(&uc, &cu, cfg, logger.InitTheLogger(cfg))
+	calls := 0
+	var resolveErr error = errors.New("network error")
+	up.SetUsernameResolver(func() (string, error) {
+		calls++
+		//The handlers can be used while the username is being resolved.
+		up.Commands()
+		return "test_bot", resolveErr
+	})
+	if _, err := up.commands.getUsername(); err != resolveErr {
+		t.Fatalf("expected the error of the resolver, got %v", err)
+	}
+	//The resolver is not called again right after failing.
+	if _, err := up.commands.getUsername(); err != errUsernameUnavailable || calls != 1 {
+		t.Fatalf("the resolver was called %d times, error : %v", calls, err)
+	}
+	up.commands.mx.Lock()
+	up.commands.retryAt = time.Now()
+	up.commands.mx.Unlock()
+	resolveErr = nil
+	if username, err := up.commands.getUsername(); err != nil || username != "test_bot" || calls != 2 {
+		t.Fatalf("unexpected result after retrying : %q %v, %d calls", username, err, calls)
+	}
+	if username, _ := up.commands.getUsername(); username != "test_bot" || calls != 2 {
+		t.Fatal("the resolved username was not kept")
+	}
+
+	//Commands with an empty entity are ignored.
+	update := &objs.Update{Message: &objs.Message{Text: "/ban", Chat: &objs.Chat{Type: "private"}, Entities: []objs.MessageEntity{{Type: "bot_command", Length: 0}}}}
+	if up.checkCommandHandlers(update) {
+		t.Fatal("a command with an empty entity was routed")
+	}
+}
@@ -0,0 +1,164 @@
+package parser
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+dispatcher executes the middleware chain of the updates using a fixed number of workers. Every worker has it's own queue and the updates of the same chat (or user) are always sent to the same worker,
+so they are processed in the order they were received. Dispatching blocks when the queue of the worker is full.
+
+The workers are started when the first update is dispatched and they are stopped by "close". They are started again if another update is dispatched after that.
+*/
+type dispatcher struct {
+	mx        sync.RWMutex
+	workers   int
+	queueSize int
+	execute   func(up *objs.Update)
+	queues    []chan *objs.Update
+	//closing is closed when the dispatcher is being closed, so the blocked dispatches return. closeMx guards closing it.
+	closing chan struct{}
+	closeMx sync.Mutex
+}
+
+func newDispatcher(cfg *configs.DispatcherConfigs, execute func(up *objs.Update)) *dispatcher {
+	workers, queueSize := cfg.Workers, cfg.QueueSize
+	if workers <= 0 {
+		workers = 4 * runtime.NumCPU()
+	}
+	if queueSize <= 0 {
+		queueSize = 64
+	}
+	return &dispatcher{workers: workers, queueSize: queueSize, execute: execute}
+}
+
+/*Starts the workers if they are not running.*/
+func (d *dispatcher) start() {
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	if d.queues != nil {
+		return
+	}
+	d.closing = make(chan struct{})
+	d.queues = make([]chan *objs.Update, d.workers)
+	for i := range d.queues {
+		d.queues[i] = make(chan *objs.Update, d.queueSize)
+		go d.startWorker(d.queues[i])
+	}
+}
+
+func (d *dispatcher) startWorker(queue chan *objs.Update) {
+	for up := range queue {
+		d.execute(up)
+	}
+}
+
+/*
+Adds the update to the queue of it's worker. Blocks until there is room in the queue.
+Returns false if the dispatcher is closed while waiting, in this case the update is not processed.
+*/
+func (d *dispatcher) dispatch(up *objs.Update) bool {
+	key := updateKey(up)
+	if key < 0 {
+		key = -key
+	}
+	d.mx.RLock()
+	for d.queues == nil {
+		d.mx.RUnlock()
+		d.start()
+		d.mx.RLock()
+	}
+	defer d.mx.RUnlock()
+	select {
+	case d.queues[key%int64(len(d.queues))] <- up:
+		return true
+	case <-d.closing:
+		return false
+	}
+}
+
+/*
+Stops the workers. The updates which are already in the queues are still processed before the workers exit, but the blocked dispatches return without adding their updates.
+This method does not wait for the workers to exit.
+*/
+func (d *dispatcher) close() {
+	d.mx.RLock()
+	closing := d.closing
+	d.mx.RUnlock()
+	if closing == nil {
+		return
+	}
+	//Unblocks the dispatches which are waiting for room in the queues, so the lock can be acquired.
+	d.closeMx.Lock()
+	select {
+	case <-closing:
+	default:
+		close(closing)
+	}
+	d.closeMx.Unlock()
+	d.mx.Lock()
+	defer d.mx.Unlock()
+	if d.closing != closing {
+		return
+	}
+	for _, queue := range d.queues {
+		close(queue)
+	}
+	d.queues = nil
+	d.closing = nil
+}
+
+/*Returns the id of the chat of the update, or the id of the user if the update doesn't belong to a chat. If none of them exist, the update id is returned.*/
+func updateKey(up *objs.Update) int64 {
+	if chat := updateChat(up); chat != nil {
+		return int64(chat.Id)
+	}
+	if user := updateUser(up); user != nil {
+		return int64(user.Id)
+	}
+	return int64(up.Update_id)
+}
+
+func updateChat(up *objs.Update) *objs.Chat {
+	switch {
+	case up.Message != nil:
+		return up.Message.Chat
+	case up.EditedMessage != nil:
+		return up.EditedMessage.Chat
+	case up.ChannelPost != nil:
+		return up.ChannelPost.Chat
+	case up.EditedChannelPost != nil:
+		return up.EditedChannelPost.Chat
+	case up.MyChatMember != nil:
+		return up.MyChatMember.Chat
+	case up.ChatMember != nil:
+		return up.ChatMember.Chat
+	case up.ChatJoinRequest != nil:
+		return up.ChatJoinRequest.Chat
+	case up.CallbackQuery != nil:
+		return up.CallbackQuery.Message.Chat
+	}
+	return nil
+}
+
+func updateUser(up *objs.Update) *objs.User {
+	switch {
+	case up.InlineQuery != nil:
+		return up.InlineQuery.From
+	case up.ChosenInlineResult != nil:
+		return &up.ChosenInlineResult.From
+	case up.CallbackQuery != nil:
+		return &up.CallbackQuery.From
+	case up.ShippingQuery != nil:
+		return up.ShippingQuery.From
+	case up.PreCheckoutQuery != nil:
+		return up.PreCheckoutQuery.From
+	case up.PollAnswer != nil:
+		return up.PollAnswer.User
+	}
+	return nil
+}
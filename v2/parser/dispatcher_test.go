@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func createDispatcherParser(workers, queueSize int) *UpdateParser {
+	cfg := configs.Default("token")
+	cfg.DispatcherConfigs = &configs.DispatcherConfigs{Workers: workers, QueueSize: queueSize}
+	uc := make(chan *objs.Update)
+	cu := make(chan *objs.ChatUpdate)
+	return CreateUpdateParser(&uc, &cu, cfg, logger.InitTheLogger(cfg))
+}
+
+func chatUpdate(id, chatId int) *objs.Update {
+	return &objs.Update{Update_id: id, Message: &objs.Message{Chat: &objs.Chat{Id: chatId}}}
+}
+
+func TestDispatcherKeepsChatOrder(t *testing.T) {
+	up := createDispatcherParser(2, 4)
+	var mx sync.Mutex
+	received := make(map[int][]int)
+	up.AddMiddleWare(func(update *objs.Update, next func()) {
+		//Later updates take less time, so they would finish first if they were run concurrently.
+		time.Sleep(time.Duration(20-update.Update_id%20) * time.Millisecond / 10)
+		mx.Lock()
+		chat := update.Message.Chat.Id
+		received[chat] = append(received[chat], update.Update_id)
+		mx.Unlock()
+	})
+	for i := 1; i <= 40; i++ {
+		up.ExecuteChainAsync(chatUpdate(i, -100-i%3))
+	}
+	if err := up.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	total := 0
+	for chat, ids := range received {
+		total += len(ids)
+		for i := 1; i < len(ids); i++ {
+			if ids[i] < ids[i-1] {
+				t.Fatalf("updates of chat %d were processed out of order : %v", chat, ids)
+			}
+		}
+	}
+	if total != 40 {
+		t.Fatalf("expected 40 processed updates, got %d", total)
+	}
+}
+
+func TestDispatcherRunsChatsConcurrently(t *testing.T) {
+	up := createDispatcherParser(2, 4)
+	release := make(chan struct{})
+	done := make(chan int, 2)
+	up.AddMiddleWare(func(update *objs.Update, next func()) {
+		if update.Message.Chat.Id == 1 {
+			<-release
+		}
+		done <- update.Message.Chat.Id
+	})
+	up.ExecuteChainAsync(chatUpdate(1, 1))
+	up.ExecuteChainAsync(chatUpdate(2, 2))
+	select {
+	case chat := <-done:
+		if chat != 2 {
+			t.Fatalf("unexpected chat %d", chat)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("an update of another chat was blocked")
+	}
+	close(release)
+	<-done
+}
+
+func TestDispatcherBackpressure(t *testing.T) {
+	up := createDispatcherParser(1, 1)
+	release := make(chan struct{})
+	up.AddMiddleWare(func(update *objs.Update, next func()) {
+		<-release
+	})
+	//The first update is being processed and the second one fills the queue.
+	up.ExecuteChainAsync(chatUpdate(1, 1))
+	up.ExecuteChainAsync(chatUpdate(2, 1))
+	dispatched := make(chan struct{})
+	go func() {
+		up.ExecuteChainAsync(chatUpdate(3, 1))
+		close(dispatched)
+	}()
+	select {
+	case <-dispatched:
+		t.Fatal("dispatching did not block while the queue was full")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	select {
+	case <-dispatched:
+	case <-time.After(time.Second):
+		t.Fatal("dispatching was not resumed")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := up.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestDispatcherClose(t *testing.T) {
+	before := runtime.NumGoroutine()
+	up := createDispatcherParser(1, 1)
+	release := make(chan struct{})
+	var mx sync.Mutex
+	var processed []int
+	up.AddMiddleWare(func(update *objs.Update, next func()) {
+		<-release
+		mx.Lock()
+		processed = append(processed, update.Update_id)
+		mx.Unlock()
+	})
+	up.ExecuteChainAsync(chatUpdate(1, 1))
+	up.ExecuteChainAsync(chatUpdate(2, 1))
+	dispatched := make(chan struct{})
+	go func() {
+		up.ExecuteChainAsync(chatUpdate(3, 1))
+		close(dispatched)
+	}()
+	time.Sleep(20 * time.Millisecond)
+	up.Close()
+	select {
+	case <-dispatched:
+	case <-time.After(time.Second):
+		t.Fatal("closing did not unblock the waiting dispatch")
+	}
+	close(release)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := up.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mx.Lock()
+	if len(processed) != 2 {
+		t.Fatalf("expected the queued updates to be processed, got %v", processed)
+	}
+	mx.Unlock()
+	//The workers exit after the queues are drained.
+	for i := 0; runtime.NumGoroutine() > before; i++ {
+		if i == 100 {
+			t.Fatalf("workers are still running : %d goroutines, %d before", runtime.NumGoroutine(), before)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	//The workers are started again for new updates.
+	up.ExecuteChainAsync(chatUpdate(4, 1))
+	if err := up.Wait(ctx); err != nil {
+		t.Fatal(err)
+	}
+	up.Close()
+}
@@ -0,0 +1,95 @@
+package parser
+
+import (
+	"sort"
+	"strings"
+	"sync"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+// HandlerInfo describes a registered text handler. It's returned by "Handlers" method for debugging.
+type HandlerInfo struct {
+	Id        int
+	Pattern   string
+	ChatTypes []string
+	Priority  int
+}
+
+/*
+handlerList keeps the text handlers ordered by their priority. Handlers with higher priority are checked first. Among the handlers with the same priority, a handler is checked before the handlers
+which are more general than it (their regex matches it's pattern, like "hi" and "hi guys"), otherwise the handlers are checked in the order they were added.
+The first handler which matches the text and the chat type of the message is selected.
+*/
+type handlerList struct {
+	mx       sync.RWMutex
+	handlers []*handler
+	lastId   int
+}
+
+// AddHandler adds a new handler to the list and returns it's id.
+func (hl *handlerList) AddHandler(hdl *handler) int {
+	hl.mx.Lock()
+	defer hl.mx.Unlock()
+	hl.lastId++
+	hdl.id = hl.lastId
+	//The position after all the handlers with the same or higher priority.
+	i := sort.Search(len(hl.handlers), func(i int) bool {
+		return hl.handlers[i].priority < hdl.priority
+	})
+	//The handler is moved before the first handler with the same priority which is more general than it.
+	for j := i - 1; j >= 0 && hl.handlers[j].priority == hdl.priority; j-- {
+		if isMoreGeneral(hl.handlers[j], hdl) {
+			i = j
+		}
+	}
+	hl.handlers = append(hl.handlers, nil)
+	copy(hl.handlers[i+1:], hl.handlers[i:])
+	hl.handlers[i] = hdl
+	return hdl.id
+}
+
+/*Returns true if the regex of "general" matches the pattern of "specific" but not the other way around. Handlers with the same patterns are not more general than each other.*/
+func isMoreGeneral(general, specific *handler) bool {
+	return general.regex.MatchString(specific.regex.String()) && !specific.regex.MatchString(general.regex.String())
+}
+
+// RemoveHandler removes the handler with the given id. Returns false if no such handler exists.
+func (hl *handlerList) RemoveHandler(id int) bool {
+	hl.mx.Lock()
+	defer hl.mx.Unlock()
+	for i, hdl := range hl.handlers {
+		if hdl.id == id {
+			hl.handlers = append(hl.handlers[:i], hl.handlers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// GetHandler gets the proper handler for the given text.
+func (hl *handlerList) GetHandler(msg *objs.Message) *handler {
+	msgText := msg.Text
+	if msg.Caption != "" {
+		msgText = msg.Caption
+	}
+	hl.mx.RLock()
+	defer hl.mx.RUnlock()
+	for _, hdl := range hl.handlers {
+		if matchesChatType(hdl.chatType, msg.Chat.Type) && hdl.regex.MatchString(msgText) {
+			return hdl
+		}
+	}
+	return nil
+}
+
+// List returns the informations of the handlers in the order they are checked.
+func (hl *handlerList) List() []HandlerInfo {
+	hl.mx.RLock()
+	defer hl.mx.RUnlock()
+	out := make([]HandlerInfo, len(hl.handlers))
+	for i, hdl := range hl.handlers {
+		out[i] = HandlerInfo{Id: hdl.id, Pattern: hdl.regex.String(), ChatTypes: strings.Split(hdl.chatType, ","), Priority: hdl.priority}
+	}
+	return out
+}
@@ -1,13 +1,14 @@
 package parser
 
 import (
+	"reflect"
 	"regexp"
 	"testing"
 
 	"github.com/SakoDroid/telego/v2/objects"
 )
 
-var tree = &handlerTree{}
+var tree = &handlerList{}
 var testTable []handlerTest
 
 type handlerTest struct {
@@ -74,3 +75,37 @@ func initTheTable() {
 	test15 := handlerTest{msg: &objects.Message{Caption: "start again", Chat: &objects.Chat{Type: "private"}}, expectedRegex: "start again"}
 	testTable = []handlerTest{test1, test2, test3, test4, test5, test6, test7, test8, test9, test10, test11, test12, test13, test14, test15}
 }
+
+func TestHandlerOrderAndRemoval(t *testing.T) {
+	hl := &handlerList{}
+	first := hl.AddHandler(&handler{regex: regexp.MustCompile("^hi"), chatType: "all"})
+	second := hl.AddHandler(&handler{regex: regexp.MustCompile("hi"), chatType: "all"})
+	urgent := hl.AddHandler(&handler{regex: regexp.MustCompile("hi there"), chatType: "group,supergroup", priority: 5})
+	//"hi guys" is more specific than the handlers which are added before it, so it's checked first.
+	specific := hl.AddHandler(&handler{regex: regexp.MustCompile("hi guys"), chatType: "all"})
+	msg := func(text, chatType string) *objects.Message {
+		return &objects.Message{Text: text, Chat: &objects.Chat{Type: chatType}}
+	}
+	if hdl := hl.GetHandler(msg("hi there", "supergroup")); hdl == nil || hdl.id != urgent {
+		t.Fatal("the handler with the higher priority was not selected")
+	}
+	if hdl := hl.GetHandler(msg("hi there", "private")); hdl == nil || hdl.id != first {
+		t.Fatal("the handler which was added first was not selected")
+	}
+	if hdl := hl.GetHandler(msg("hi guys", "private")); hdl == nil || hdl.id != specific {
+		t.Fatal("the more specific handler was not selected")
+	}
+	var ids []int
+	for _, info := range hl.List() {
+		ids = append(ids, info.Id)
+	}
+	if !reflect.DeepEqual(ids, []int{urgent, specific, first, second}) {
+		t.Fatalf("handlers are listed in the wrong order : %v", ids)
+	}
+	if !hl.RemoveHandler(first) || hl.RemoveHandler(first) {
+		t.Fatal("the handler was not removed exactly once")
+	}
+	if hdl := hl.GetHandler(msg("hi there", "private")); hdl == nil || hdl.id != second {
+		t.Fatal("the removed handler was selected")
+	}
+}
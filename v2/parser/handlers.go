@@ -7,12 +7,13 @@ import (
 	objs "github.com/SakoDroid/telego/v2/objects"
 )
 
-// var handlers = handlerTree{}
 // var callbackHandlers = threadSafeMap[string, *callbackHandler]{internal: make(map[string]*callbackHandler)}
 // var userSharedHandlers = threadSafeMap[int, *chatRequestHandler]{internal: make(map[int]*chatRequestHandler)}
 // var chatSharedHandlers = threadSafeMap[int, *chatRequestHandler]{internal: make(map[int]*chatRequestHandler)}
 
 type handler struct {
+	id       int                 //The id which is used for removing the handler.
+	priority int                 //Handlers with higher priority are checked first.
 	regex    *regexp.Regexp      //The compiled regex.
 	chatType string              //The ChatType this handler will act on
 	function *func(*objs.Update) //The function to be executed
@@ -24,14 +25,33 @@ type callbackHandler struct {
 }
 
 func (up *UpdateParser) AddHandler(patern string, handlerFunc func(*objs.Update), chatType ...string) error {
-	hl := handler{chatType: strings.Join(chatType, ","), function: &handlerFunc}
+	_, err := up.AddHandlerWithPriority(patern, 0, handlerFunc, chatType...)
+	return err
+}
+
+/*
+AddHandlerWithPriority adds a text handler with the given priority and returns it's id. Handlers with higher priority are checked first. Handlers with the same priority are checked
+from the specific ones to the general ones (a handler whose regex matches the pattern of another handler is checked after it), otherwise in the order they were added.
+The id can be passed to "RemoveHandler" to remove the handler.
+*/
+func (up *UpdateParser) AddHandlerWithPriority(patern string, priority int, handlerFunc func(*objs.Update), chatType ...string) (int, error) {
+	hl := handler{priority: priority, chatType: strings.Join(chatType, ","), function: &handlerFunc}
 	rgxp, err := regexp.Compile(patern)
 	if err != nil {
-		return err
+		return 0, err
 	}
 	hl.regex = rgxp
-	up.handlers.AddHandler(&hl)
-	return nil
+	return up.handlers.AddHandler(&hl), nil
+}
+
+// RemoveHandler removes the text handler with the given id. Returns false if no such handler exists.
+func (up *UpdateParser) RemoveHandler(id int) bool {
+	return up.handlers.RemoveHandler(id)
+}
+
+// Handlers returns the registered text handlers in the order they are checked.
+func (up *UpdateParser) Handlers() []HandlerInfo {
+	return up.handlers.List()
 }
 
 func (up *UpdateParser) AddCallbackHandler(data string, handlerFun func(*objs.Update)) {
@@ -69,7 +89,7 @@ func (up *UpdateParser) checkHandlers(update *objs.Update) bool {
 	}
 
 	if update.Message == nil {
-		return false
+		return up.checkUpdateHandlers(update)
 	}
 
 	if update.Message.UserShared != nil {
@@ -80,22 +100,25 @@ func (up *UpdateParser) checkHandlers(update *objs.Update) bool {
 		return up.checkChatSharedHandlers(update)
 	}
 
+	if up.checkCommandHandlers(update) {
+		return true
+	}
 	return up.checkTextMsgHandlers(update)
 }
 
 func (up *UpdateParser) checkCallbackHanlders(update *objs.Update) bool {
 	hdl, ok := up.callbackHandlers.Load(update.CallbackQuery.Data)
 	if ok && hdl != nil {
-		go (*hdl.function)(update)
+		up.runHandler(*hdl.function, update)
 		return true
 	}
-	return false
+	return up.checkCallbackRoutes(update)
 }
 
 func (up *UpdateParser) checkUserSharedHandlers(update *objs.Update) bool {
 	hdl, ok := up.userSharedHandlers.LoadAndDelete(update.Message.UserShared.RequestId)
 	if ok && hdl != nil && hdl.function != nil {
-		go (*hdl.function)(update)
+		up.runHandler(*hdl.function, update)
 		return true
 	}
 	return false
@@ -104,7 +127,7 @@ func (up *UpdateParser) checkUserSharedHandlers(update *objs.Update) bool {
 func (up *UpdateParser) checkChatSharedHandlers(update *objs.Update) bool {
 	hdl, ok := up.chatSharedHandlers.Load(update.Message.ChatShared.RequestId)
 	if ok && hdl != nil && hdl.function != nil {
-		go (*hdl.function)(update)
+		up.runHandler(*hdl.function, update)
 		return true
 	}
 	return false
@@ -114,7 +137,7 @@ func (up *UpdateParser) checkTextMsgHandlers(update *objs.Update) bool {
 	if update.Message != nil && (update.Message.Text != "" || update.Message.Caption != "") {
 		hndl := up.handlers.GetHandler(update.Message)
 		if hndl != nil {
-			go (*hndl.function)(update)
+			up.runHandler(*hndl.function, update)
 			return true
 		}
 	}
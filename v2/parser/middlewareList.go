@@ -6,8 +6,6 @@ import (
 	objs "github.com/SakoDroid/telego/v2/objects"
 )
 
-var middlewares = &middlewareLinkedList{}
-
 type middlewareListMember struct {
 	prev, next *middlewareListMember
 	internal   func(*objs.Update, func())
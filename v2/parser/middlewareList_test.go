@@ -3,6 +3,8 @@ package parser
 import (
 	"testing"
 
+	"github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/logger"
 	objs "github.com/SakoDroid/telego/v2/objects"
 )
 
@@ -38,3 +40,28 @@ func TestMiddleList(t *testing.T) {
 		t.FailNow()
 	}
 }
+
+func TestMiddlewaresArePerParser(t *testing.T) {
+	cfg := configs.Default("token")
+	botLogger := logger.InitTheLogger(cfg)
+	uc1, uc2 := make(chan *objs.Update, 1), make(chan *objs.Update, 1)
+	cu1, cu2 := make(chan *objs.ChatUpdate, 1), make(chan *objs.ChatUpdate, 1)
+	up1 := CreateUpdateParser(&uc1, &cu1, cfg, botLogger)
+	up2 := CreateUpdateParser(&uc2, &cu2, cfg, botLogger)
+	calls := 0
+	up1.AddMiddleWare(func(update *objs.Update, next func()) {
+		calls++
+		next()
+	})
+	up2.ExecuteChain(&objs.Update{Update_id: 1})
+	if calls != 0 {
+		t.Error("middleware of the first parser has been executed by the second parser")
+	}
+	up1.ExecuteChain(&objs.Update{Update_id: 2})
+	if calls != 1 {
+		t.Error("middleware has not been executed")
+	}
+	if len(uc1) != 1 || len(uc2) != 1 {
+		t.Error("each parser should deliver the update to it's own channel")
+	}
+}
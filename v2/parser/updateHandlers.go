@@ -0,0 +1,118 @@
+package parser
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*updateHandler is a handler for an update type other than messages and callback queries.*/
+type updateHandler struct {
+	chatType string              //The chat types this handler will act on. Empty for all chats.
+	function *func(*objs.Update) //The function to be executed
+}
+
+/*The update types which handlers can be added for. The value is true if the updates of the type belong to a chat. Edited messages and channel posts have text handlers (see "AddMessageHandler").*/
+var handledUpdateTypes = map[string]bool{
+	"inline_query":         false,
+	"chosen_inline_result": false,
+	"shipping_query":       false,
+	"pre_checkout_query":   false,
+	"poll_answer":          false,
+	"my_chat_member":       true,
+	"chat_member":          true,
+	"chat_join_request":    true,
+}
+
+/*
+AddUpdateHandler adds a handler for the given update type (for example "inline_query" or "chat_member"). Updates which belong to a chat can be filtered by the chat type, if no chat type is given the handler acts on all chats.
+If several handlers match an update, the handler which has been added first is executed.
+*/
+func (up *UpdateParser) AddUpdateHandler(updateType string, handlerFunc func(*objs.Update), chatTypes ...string) error {
+	if _, ok := up.messageHandlers[updateType]; ok {
+		return errors.New(updateType + " updates are handled by text handlers, use AddMessageHandler")
+	}
+	isChat, ok := handledUpdateTypes[updateType]
+	if !ok {
+		return errors.New("handlers can not be added for update type : " + updateType)
+	}
+	if !isChat && len(chatTypes) != 0 {
+		return errors.New(updateType + " updates don't belong to a chat, so they can not be filtered by chat type")
+	}
+	hl := &updateHandler{chatType: strings.Join(chatTypes, ","), function: &handlerFunc}
+	up.updateHandlers.Lock()
+	up.updateHandlers.internal[updateType] = append(up.updateHandlers.internal[updateType], hl)
+	up.updateHandlers.Unlock()
+	return nil
+}
+
+/*
+AddMessageHandler adds a text handler for the edited messages or the channel posts ("edited_message", "channel_post" or "edited_channel_post"). The handler acts on the updates which their text
+(or caption) matches the given regex pattern, and it can be filtered by chat type like the handlers of the new messages. The handlers are ordered the same way as "AddHandlerWithPriority" orders them.
+*/
+func (up *UpdateParser) AddMessageHandler(updateType, pattern string, handlerFunc func(*objs.Update), chatTypes ...string) error {
+	list, ok := up.messageHandlers[updateType]
+	if !ok {
+		return errors.New("message handlers can not be added for update type : " + updateType)
+	}
+	rgxp, err := regexp.Compile(pattern)
+	if err != nil {
+		return err
+	}
+	list.AddHandler(&handler{regex: rgxp, chatType: strings.Join(chatTypes, ","), function: &handlerFunc})
+	return nil
+}
+
+/*Returns the message of the edited messages and the channel posts.*/
+func handledMessage(update *objs.Update) *objs.Message {
+	switch {
+	case update.EditedMessage != nil:
+		return update.EditedMessage
+	case update.ChannelPost != nil:
+		return update.ChannelPost
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost
+	}
+	return nil
+}
+
+func (up *UpdateParser) checkUpdateHandlers(update *objs.Update) bool {
+	if msg := handledMessage(update); msg != nil {
+		hdl := up.messageHandlers[update.GetType()].GetHandler(msg)
+		if hdl == nil {
+			return false
+		}
+		up.runHandler(*hdl.function, update)
+		return true
+	}
+	hdls, ok := up.updateHandlers.Load(update.GetType())
+	if !ok {
+		return false
+	}
+	chatType := ""
+	if chat := updateChat(update); chat != nil {
+		chatType = chat.Type
+	}
+	for _, hdl := range hdls {
+		if matchesChatType(hdl.chatType, chatType) {
+			up.runHandler(*hdl.function, update)
+			return true
+		}
+	}
+	return false
+}
+
+/*Checks if the chat type is in the given comma separated chat types. Empty chat types match all chats.*/
+func matchesChatType(chatTypes, chatType string) bool {
+	if chatTypes == "" {
+		return true
+	}
+	for _, ct := range strings.Split(chatTypes, ",") {
+		if ct == "all" || ct == chatType {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,107 @@
+package parser
+
+import (
+	"context"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func TestUpdateHandlers(t *testing.T) {
+	cfg := configs.Default("token")
+	uc := make(chan *objs.Update, 10)
+	cu := make(chan *objs.ChatUpdate, 10)
+	up := CreateUpdateParser(&uc, &cu, cfg, logger.InitTheLogger(cfg))
+	var mx sync.Mutex
+	var handled []string
+	record := func(name string) func(*objs.Update) {
+		return func(*objs.Update) {
+			mx.Lock()
+			handled = append(handled, name)
+			mx.Unlock()
+		}
+	}
+	if err := up.AddUpdateHandler("inline_query", record("inline"), "private"); err == nil {
+		t.Fatal("inline queries should not accept chat types")
+	}
+	if err := up.AddUpdateHandler("unknown", record("unknown")); err == nil {
+		t.Fatal("unknown update types should be rejected")
+	}
+	up.AddUpdateHandler("inline_query", record("inline"))
+	up.AddUpdateHandler("chat_member", record("group member"), "group")
+	up.AddUpdateHandler("chat_member", record("channel member"), "channel")
+
+	member := func(id int, chatType string) *objs.Update {
+		return &objs.Update{Update_id: id, ChatMember: &objs.ChatMemberUpdated{Chat: &objs.Chat{Id: -id, Type: chatType}}}
+	}
+	up.ExecuteChain(&objs.Update{Update_id: 1, InlineQuery: &objs.InlineQuery{From: &objs.User{Id: 1}}})
+	up.ExecuteChain(member(2, "channel"))
+	up.ExecuteChain(member(3, "supergroup"))
+	if err := up.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(handled) != 2 || handled[0] == handled[1] {
+		t.Fatalf("unexpected handlers were executed : %v", handled)
+	}
+	//The update which doesn't match any handler is passed to the chat channel.
+	select {
+	case cup := <-cu:
+		if cup.Update.Update_id != 3 {
+			t.Fatalf("update %d was passed to the channel", cup.Update.Update_id)
+		}
+	default:
+		t.Fatal("the unhandled update was not passed to the channel")
+	}
+}
+
+func TestMessageHandlers(t *testing.T) {
+	cfg := configs.Default("token")
+	uc := make(chan *objs.Update, 10)
+	cu := make(chan *objs.ChatUpdate, 10)
+	up := CreateUpdateParser(&uc, &cu, cfg, logger.InitTheLogger(cfg))
+	var mx sync.Mutex
+	var handled []string
+	record := func(name string) func(*objs.Update) {
+		return func(*objs.Update) {
+			mx.Lock()
+			handled = append(handled, name)
+			mx.Unlock()
+		}
+	}
+	if err := up.AddUpdateHandler("edited_message", record("edited")); err == nil {
+		t.Fatal("edited messages should be handled by message handlers")
+	}
+	if err := up.AddMessageHandler("edited_message", "(", record("edited")); err == nil {
+		t.Fatal("invalid patterns should be rejected")
+	}
+	up.AddMessageHandler("edited_message", "hi", record("edited hi"), "private")
+	up.AddMessageHandler("edited_message", "hi guys", record("edited hi guys"), "private")
+	up.AddMessageHandler("channel_post", "^news", record("news"))
+
+	chat := &objs.Chat{Id: 10, Type: "private"}
+	channel := &objs.Chat{Id: -10, Type: "channel"}
+	up.ExecuteChain(&objs.Update{Update_id: 1, EditedMessage: &objs.Message{Text: "hi guys", Chat: chat}})
+	up.ExecuteChain(&objs.Update{Update_id: 2, EditedMessage: &objs.Message{Caption: "hi", Chat: chat}})
+	up.ExecuteChain(&objs.Update{Update_id: 3, ChannelPost: &objs.Message{Text: "news of today", Chat: channel}})
+	//The updates which don't match the pattern or the chat type are not handled.
+	up.ExecuteChain(&objs.Update{Update_id: 4, EditedChannelPost: &objs.Message{Text: "news of today", Chat: channel}})
+	up.ExecuteChain(&objs.Update{Update_id: 5, ChannelPost: &objs.Message{Text: "old news", Chat: channel}})
+	up.ExecuteChain(&objs.Update{Update_id: 6, EditedMessage: &objs.Message{Text: "hi", Chat: &objs.Chat{Id: -20, Type: "group"}}})
+	if err := up.Wait(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	mx.Lock()
+	defer mx.Unlock()
+	sort.Strings(handled)
+	if !reflect.DeepEqual(handled, []string{"edited hi", "edited hi guys", "news"}) {
+		t.Fatalf("unexpected handlers were executed : %v", handled)
+	}
+	if len(cu) != 3 {
+		t.Fatalf("expected the unhandled updates to be passed to the chat channels, %d updates were passed", len(cu))
+	}
+}
@@ -1,10 +1,15 @@
 package parser
 
 import (
+	"context"
 	"fmt"
+	"runtime/debug"
 	"strconv"
+	"sync"
+	"sync/atomic"
 
 	"github.com/SakoDroid/telego/v2/configs"
+	errs "github.com/SakoDroid/telego/v2/errors"
 	"github.com/SakoDroid/telego/v2/logger"
 	objs "github.com/SakoDroid/telego/v2/objects"
 )
@@ -13,16 +18,179 @@ type UpdateParser struct {
 	uc                 *chan *objs.Update
 	cu                 *chan *objs.ChatUpdate
 	cfg                *configs.BotConfigs
-	handlers           *handlerTree
+	handlers           *handlerList
 	callbackHandlers   threadSafeMap[string, *callbackHandler]
 	userSharedHandlers threadSafeMap[int, *chatRequestHandler]
 	chatSharedHandlers threadSafeMap[int, *chatRequestHandler]
+	updateHandlers     threadSafeMap[string, []*updateHandler]
+	messageHandlers    map[string]*handlerList //The text handlers of the edited messages and channel posts, mapped by update type.
+	commands           *commandRouter
+	callbackRoutes     *callbackRouter
+	callbackStore      *callbackStore
+	middlewares        *middlewareLinkedList
 	logger             *logger.BotLogger
+	//inFlight counts the middleware chains and handlers which are running.
+	inFlight   sync.WaitGroup
+	dispatcher *dispatcher
+	//processed is called after the middleware chain and the handlers of each update return.
+	processed func(up *objs.Update)
+	//work counts the running middleware chain and handlers of each update, so "processed" is called when all of them have returned. It's only used when "processed" is set or the update is in "notify".
+	work threadSafeMap[*objs.Update, *int32]
+	//notify keeps the functions which are called when the updates passed to "DispatchAndNotify" are processed.
+	notify threadSafeMap[*objs.Update, func()]
 }
 
 // ExecuteChain executes the chained middlewares
 func (u *UpdateParser) ExecuteChain(up *objs.Update) {
-	middlewares.executeChain(up)
+	u.inFlight.Add(1)
+	defer u.inFlight.Done()
+	u.execute(up)
+}
+
+/*
+ExecuteChainAsync executes the chained middlewares in a new goroutine. The execution is counted by "Wait" as soon as this method returns.
+If the dispatcher is enabled in the configs, the update is passed to the dispatcher instead and this method blocks while the queue of the dispatcher is full.
+*/
+func (u *UpdateParser) ExecuteChainAsync(up *objs.Update) {
+	u.inFlight.Add(1)
+	if u.dispatcher != nil {
+		if !u.dispatcher.dispatch(up) {
+			//The dispatcher has been closed while waiting for room in the queue.
+			u.notify.Delete(up)
+			u.inFlight.Done()
+		}
+		return
+	}
+	go func() {
+		defer u.inFlight.Done()
+		u.execute(up)
+	}()
+}
+
+/*
+Dispatch passes the update to the dispatcher if it's enabled in the configs, so it's processed in order with the other updates of it's chat. This method blocks while the queue of the dispatcher is full.
+If the dispatcher is not enabled, the middlewares are executed in the calling goroutine.
+*/
+func (u *UpdateParser) Dispatch(up *objs.Update) {
+	if u.dispatcher != nil {
+		u.ExecuteChainAsync(up)
+	} else {
+		u.ExecuteChain(up)
+	}
+}
+
+/*
+DispatchAndNotify works like "Dispatch" and calls "done" after the middleware chain of the update and all the handlers which have been run for it return (even if they panic).
+"done" is not called if the update is dropped because the dispatcher has been closed.
+*/
+func (u *UpdateParser) DispatchAndNotify(up *objs.Update, done func()) {
+	u.notify.Add(up, done)
+	u.Dispatch(up)
+}
+
+/*
+Wait waits until all the running middleware chains and handlers return. Returns the context's error if the context is cancelled before that.
+New updates should not be passed to the parser while waiting.
+*/
+func (u *UpdateParser) Wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		u.inFlight.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/*
+Close stops the workers of the dispatcher (if it's enabled). The updates which are already in the queues of the workers are still processed. The workers are started again if more updates are passed to the parser.
+The bot closes it's parser when it's stopped.
+*/
+func (u *UpdateParser) Close() {
+	if u.dispatcher != nil {
+		u.dispatcher.close()
+	}
+}
+
+/*
+SetProcessedHook sets the function which is called after the middleware chain of each update and all the handlers which have been run for it return (even if they panic). It should be set before any updates are passed to the parser.
+Updates which are passed to the update channels are considered processed as soon as they are passed.
+*/
+func (u *UpdateParser) SetProcessedHook(hook func(up *objs.Update)) {
+	u.processed = hook
+}
+
+/*Executes the middleware chain. Panics are recovered and reported to the "OnError" hook.*/
+func (u *UpdateParser) execute(up *objs.Update) {
+	if _, notify := u.notify.Load(up); notify || u.processed != nil {
+		u.work.Add(up, new(int32))
+		u.startWork(up)
+		defer u.finishWork(up)
+	}
+	defer u.recoverPanic("middleware", up)
+	u.middlewares.executeChain(up)
+}
+
+/*Counts a running middleware chain or handler of the update, if the update is being tracked.*/
+func (u *UpdateParser) startWork(up *objs.Update) {
+	if n, ok := u.work.Load(up); ok {
+		atomic.AddInt32(n, 1)
+	}
+}
+
+/*Must be called when the work started by "startWork" returns. The processed hook is called when all the work of the update has returned.*/
+func (u *UpdateParser) finishWork(up *objs.Update) {
+	n, ok := u.work.Load(up)
+	if !ok {
+		return
+	}
+	if atomic.AddInt32(n, -1) == 0 {
+		u.work.Delete(up)
+		if u.processed != nil {
+			u.processed(up)
+		}
+		if done, ok := u.notify.LoadAndDelete(up); ok {
+			done()
+		}
+	}
+}
+
+/*Must be deferred. Recovers the panic (if any) and passes it to the "OnError" hook of the configs, or logs it if the hook is not set.*/
+func (u *UpdateParser) recoverPanic(source string, up *objs.Update) {
+	value := recover()
+	if value == nil {
+		return
+	}
+	err := &errs.PanicError{Source: source, Value: value, Stack: debug.Stack(), Update: up}
+	if u.cfg.OnError != nil {
+		u.cfg.OnError(err)
+	} else {
+		u.logger.GetRaw().Println("Recovered from", err.Error()+"\n"+string(err.Stack))
+	}
+}
+
+/*
+Runs the given handler in a new goroutine which is counted by "Wait". If the dispatcher is enabled, the handler is run in the worker of the update to keep the order of the updates.
+In this case a handler which waits for a later update of the same chat blocks the worker forever, since that update is queued behind the handler.
+*/
+func (u *UpdateParser) runHandler(handler func(*objs.Update), update *objs.Update) {
+	if u.dispatcher != nil {
+		defer u.recoverPanic("handler", update)
+		handler(update)
+		return
+	}
+	u.inFlight.Add(1)
+	u.startWork(update)
+	go func() {
+		defer u.inFlight.Done()
+		defer u.finishWork(update)
+		defer u.recoverPanic("handler", update)
+		handler(update)
+	}()
 }
 
 // GetUpdateParserMiddleware returns a middleware that processes the given update object.
@@ -111,7 +279,7 @@ func (u *UpdateParser) checkBlocked(user *objs.User, cfg *configs.BotConfigs) (i
 }
 
 func (u *UpdateParser) AddMiddleWare(middleware func(update *objs.Update, next func())) {
-	middlewares.addToBegin(middleware)
+	u.middlewares.addToBegin(middleware)
 }
 
 func CreateUpdateParser(uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *configs.BotConfigs, botLogger *logger.BotLogger) *UpdateParser {
@@ -119,12 +287,26 @@ func CreateUpdateParser(uc *chan *objs.Update, cu *chan *objs.ChatUpdate, cfg *c
 		uc:                 uc,
 		cu:                 cu,
 		cfg:                cfg,
-		handlers:           &handlerTree{},
+		handlers:           &handlerList{},
 		callbackHandlers:   threadSafeMap[string, *callbackHandler]{internal: make(map[string]*callbackHandler)},
 		userSharedHandlers: threadSafeMap[int, *chatRequestHandler]{internal: make(map[int]*chatRequestHandler)},
 		chatSharedHandlers: threadSafeMap[int, *chatRequestHandler]{internal: make(map[int]*chatRequestHandler)},
+		updateHandlers:     threadSafeMap[string, []*updateHandler]{internal: make(map[string][]*updateHandler)},
+		messageHandlers:    map[string]*handlerList{"edited_message": {}, "channel_post": {}, "edited_channel_post": {}},
+		work:               threadSafeMap[*objs.Update, *int32]{internal: make(map[*objs.Update]*int32)},
+		notify:             threadSafeMap[*objs.Update, func()]{internal: make(map[*objs.Update]func())},
+		commands:           &commandRouter{},
+		callbackRoutes:     &callbackRouter{},
+		callbackStore:      &callbackStore{entries: make(map[string]*storedCallbackData)},
+		middlewares:        &middlewareLinkedList{},
 		logger:             botLogger,
 	}
+	if cfg.DispatcherConfigs != nil {
+		up.dispatcher = newDispatcher(cfg.DispatcherConfigs, func(update *objs.Update) {
+			defer up.inFlight.Done()
+			up.execute(update)
+		})
+	}
 
 	up.AddMiddleWare(
 		func(update *objs.Update, next func()) {
@@ -2,13 +2,42 @@ package telego
 
 import (
 	"errors"
+	"sync"
 
 	objs "github.com/SakoDroid/telego/v2/objects"
 )
 
-// Polls contains the pointers to all of the created maps.
+/*
+Polls used to contain the pointers to all of the polls which have been sent by the bots of this process.
+
+Deprecated: polls are kept per bot now and this map is not filled anymore. Use "GetPoll" method of the bot instead.
+*/
 var Polls = make(map[string]*Poll)
 
+/*pollStore keeps the polls which have been sent by a bot, so the poll updates can be delivered to them.*/
+type pollStore struct {
+	sync.RWMutex
+	internal map[string]*Poll
+}
+
+func (ps *pollStore) get(id string) *Poll {
+	ps.RLock()
+	defer ps.RUnlock()
+	return ps.internal[id]
+}
+
+func (ps *pollStore) add(p *Poll) {
+	ps.Lock()
+	defer ps.Unlock()
+	ps.internal[p.id] = p
+}
+
+func (ps *pollStore) remove(id string) {
+	ps.Lock()
+	defer ps.Unlock()
+	delete(ps.internal, id)
+}
+
 // Poll is an automatic poll.
 type Poll struct {
 	bot                                                                     *Bot
@@ -111,7 +140,6 @@ func (p *Poll) Update(poll *objs.Poll) error {
 		return errors.New("this update dos not belong to this poll")
 	}
 	p.result = poll.Options
-	Polls[p.id] = p
 	*p.updateChannel <- true
 	return nil
 }
@@ -182,7 +210,7 @@ func (p *Poll) Send(silent, protectContent bool, replyTo int) error {
 	p.result = res.Result.Poll.Options
 	ch := make(chan bool)
 	p.updateChannel = &ch
-	Polls[p.id] = p
+	p.bot.polls.add(p)
 	return nil
 }
 
@@ -207,14 +235,22 @@ func (p *Poll) SendAdvanced(replyTo, messageThreadId int, silent, allowSendingWi
 	p.result = res.Result.Poll.Options
 	ch := make(chan bool)
 	p.updateChannel = &ch
-	Polls[p.id] = p
+	p.bot.polls.add(p)
 	return nil
 }
 
-/*Stop stops the poll*/
+/*Stop stops the poll. The stopped poll is removed from the polls of the bot, so it's updates are not delivered to it anymore.*/
 func (p *Poll) Stop() error {
-	_, err := p.bot.apiInterface.StopPoll(
+	res, err := p.bot.apiInterface.StopPoll(
 		p.chatIdInt, p.chatIdString, p.messageId, nil,
 	)
-	return err
+	if err != nil {
+		return err
+	}
+	if res.Result != nil {
+		p.result = res.Result.Options
+	}
+	p.isClosed = true
+	p.bot.polls.remove(p.id)
+	return nil
 }
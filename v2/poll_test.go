@@ -0,0 +1,75 @@
+package telego_test
+
+import (
+	"testing"
+
+	telego "github.com/SakoDroid/telego/v2"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+func TestSentPollsAreKept(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	poll, err := bot.CreatePoll(10, "Which one ?", "regular")
+	if err != nil {
+		t.Fatal(err)
+	}
+	poll.AddOption("first")
+	poll.AddOption("second")
+	if err := poll.Send(false, false, 0); err != nil {
+		t.Fatal(err)
+	}
+	if poll.GetId() == "" {
+		t.Fatal("poll id has not been set")
+	}
+	if len(poll.GetResult()) != 2 {
+		t.Fatal("wrong poll options :", poll.GetResult())
+	}
+	if bot.GetPoll(poll.GetId()) != poll {
+		t.Error("poll is not kept by the bot")
+	}
+	if len(telego.Polls) != 0 {
+		t.Error("poll is kept in the deprecated polls map")
+	}
+}
+
+/*Creates and sends a poll with two options.*/
+func sendPoll(t *testing.T, bot *telego.Bot) *telego.Poll {
+	t.Helper()
+	poll, err := bot.CreatePoll(10, "Which one ?", "regular")
+	if err != nil {
+		t.Fatal(err)
+	}
+	poll.AddOption("first")
+	poll.AddOption("second")
+	if err := poll.Send(false, false, 0); err != nil {
+		t.Fatal(err)
+	}
+	return poll
+}
+
+func TestStoppedPollsAreRemoved(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	poll := sendPoll(t, bot)
+	if err := poll.Stop(); err != nil {
+		t.Fatal(err)
+	}
+	srv.ExpectCall(t, "stopPoll", nil)
+	if bot.GetPoll(poll.GetId()) != nil {
+		t.Error("stopped poll is still kept by the bot")
+	}
+	if len(poll.GetResult()) != 2 {
+		t.Error("wrong poll options after stopping :", poll.GetResult())
+	}
+}
+
+func TestClosedPollsAreRemoved(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	poll := sendPoll(t, bot)
+	srv.SendUpdate(&objs.Update{Poll: &objs.Poll{Id: poll.GetId(), Options: poll.GetResult(), IsClosed: true}})
+	<-*poll.GetUpdateChannel()
+	waitFor(t, srv, func() bool { return bot.GetPoll(poll.GetId()) == nil }, "closed poll is still kept by the bot")
+}
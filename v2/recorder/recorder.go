@@ -0,0 +1,115 @@
+/*
+Package recorder records the updates received by the bot into a file and replays them later. This can be used to capture real traffic and reproduce it in tests.
+
+Recorded files are in JSON Lines format. Each line contains the time the update was received and the raw json of the update exactly as it was received from the api server :
+
+	{"time":"2024-01-02T15:04:05.123456789Z","update":{"update_id":1,"message":{...}}}
+*/
+package recorder
+
+import (
+	"encoding/json"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+// Entry is a single line of a recorded file.
+type Entry struct {
+	/*The time the update was received.*/
+	Time time.Time `json:"time"`
+	/*The raw json of the update.*/
+	Update json.RawMessage `json:"update"`
+}
+
+/*
+Recorder writes the received updates into a writer. Use it's "Middleware" method as a middleware of the bot :
+
+	cfg.KeepRawUpdates = true
+	...
+	rec, err := recorder.Create("updates.jsonl")
+	bot.AdvancedMode().AddMiddleware(rec.Middleware)
+*/
+type Recorder struct {
+	mx     sync.Mutex
+	w      io.Writer
+	closer io.Closer
+	err    error
+}
+
+/*NewRecorder returns a recorder which writes the updates into the given writer.*/
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: w}
+}
+
+/*Create creates (or truncates) the given file and returns a recorder which writes the updates into it. The file is closed by "Close" method.*/
+func Create(path string) (*Recorder, error) {
+	fl, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	return &Recorder{w: fl, closer: fl}, nil
+}
+
+/*
+Middleware records the given update and then calls next. The update is recorded even if it's rejected by the next middlewares.
+The raw json of the updates is only available when "KeepRawUpdates" field of the bot configs is true. Otherwise (or if the update was not received from the api server) the update is recorded by encoding it to json, so the fields which are unknown to this library are lost.
+*/
+func (r *Recorder) Middleware(update *objs.Update, next func()) {
+	r.Record(update)
+	next()
+}
+
+/*Record writes the given update into the recorder. Errors are returned by "Err" method.*/
+func (r *Recorder) Record(update *objs.Update) {
+	raw := update.Raw()
+	if raw == nil {
+		var err error
+		raw, err = json.Marshal(update)
+		if err != nil {
+			r.setErr(err)
+			return
+		}
+	}
+	line, err := json.Marshal(&Entry{Time: time.Now(), Update: raw})
+	if err != nil {
+		r.setErr(err)
+		return
+	}
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if _, err = r.w.Write(append(line, '\n')); err != nil && r.err == nil {
+		r.err = err
+	}
+}
+
+/*Err returns the first error that occurred while recording the updates.*/
+func (r *Recorder) Err() error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	return r.err
+}
+
+/*Close closes the file of the recorder if it was created using "Create". Returns the first error that occurred while recording.*/
+func (r *Recorder) Close() error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if r.closer != nil {
+		if err := r.closer.Close(); err != nil && r.err == nil {
+			r.err = err
+		}
+		r.closer = nil
+	}
+	return r.err
+}
+
+func (r *Recorder) setErr(err error) {
+	r.mx.Lock()
+	if r.err == nil {
+		r.err = err
+	}
+	r.mx.Unlock()
+}
@@ -0,0 +1,87 @@
+package recorder
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+type executorFunc func(up *objs.Update)
+
+func (f executorFunc) ExecuteChain(up *objs.Update) {
+	f(up)
+}
+
+func TestRecordAndReplay(t *testing.T) {
+	buf := &bytes.Buffer{}
+	rec := NewRecorder(buf)
+	//The unknown field must be kept in the recorded file.
+	raw := `{"update_id":7,"message":{"message_id":1,"date":0,"chat":{"id":5,"type":"private"},"text":"hi"},"unknown_field":1}`
+	received := &objs.Update{}
+	if err := json.Unmarshal([]byte(raw), received); err != nil {
+		t.Fatal(err)
+	}
+	//The bot keeps the raw json when "KeepRawUpdates" is enabled.
+	received.SetRaw([]byte(raw))
+	nextCalled := false
+	rec.Middleware(received, func() { nextCalled = true })
+	if !nextCalled {
+		t.Fatal("next was not called")
+	}
+	rec.Record(&objs.Update{Update_id: 8})
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || !strings.Contains(lines[0], raw) {
+		t.Fatalf("unexpected recorded file : %s", buf.String())
+	}
+
+	var replayed []*objs.Update
+	err := Replay(context.Background(), buf, executorFunc(func(up *objs.Update) {
+		replayed = append(replayed, up)
+	}), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(replayed) != 2 || replayed[0].Update_id != 7 || replayed[0].Message.Text != "hi" || replayed[1].Update_id != 8 {
+		t.Fatalf("unexpected replayed updates : %v", replayed)
+	}
+	if string(replayed[0].Raw()) != raw {
+		t.Fatalf("raw json of the replayed update is %s", replayed[0].Raw())
+	}
+}
+
+func TestReplayOriginalSpeed(t *testing.T) {
+	start := time.Now()
+	buf := &bytes.Buffer{}
+	enc := json.NewEncoder(buf)
+	enc.Encode(&Entry{Time: start, Update: json.RawMessage(`{"update_id":1}`)})
+	enc.Encode(&Entry{Time: start.Add(100 * time.Millisecond), Update: json.RawMessage(`{"update_id":2}`)})
+	var times []time.Time
+	exec := executorFunc(func(up *objs.Update) { times = append(times, time.Now()) })
+	if err := Replay(context.Background(), bytes.NewReader(buf.Bytes()), exec, true); err != nil {
+		t.Fatal(err)
+	}
+	if len(times) != 2 || times[1].Sub(times[0]) < 100*time.Millisecond {
+		t.Fatalf("updates were not replayed at the original speed : %v", times)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	times = nil
+	exec = executorFunc(func(up *objs.Update) {
+		times = append(times, time.Now())
+		cancel()
+	})
+	if err := Replay(ctx, bytes.NewReader(buf.Bytes()), exec, true); err != context.Canceled {
+		t.Fatalf("expected context.Canceled, got %v", err)
+	}
+	if len(times) != 1 {
+		t.Fatalf("expected 1 replayed update after cancelling, got %d", len(times))
+	}
+}
@@ -0,0 +1,75 @@
+package recorder
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*Executor executes the middleware chain of the bot for an update. parser.UpdateParser implements this interface.*/
+type Executor interface {
+	ExecuteChain(up *objs.Update)
+}
+
+/*
+Replay reads the recorded updates from the given reader and passes them to the executor one by one, in the order they were recorded.
+
+If "originalSpeed" is true, the replayer waits between the updates as long as the time between receiving them, otherwise all of the updates are replayed immediately.
+Replay returns when all the updates are replayed, the context is cancelled or a line can not be decoded.
+*/
+func Replay(ctx context.Context, r io.Reader, executor Executor, originalSpeed bool) error {
+	dec := json.NewDecoder(r)
+	var last time.Time
+	for {
+		entry := &Entry{}
+		if err := dec.Decode(entry); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return err
+		}
+		update := &objs.Update{}
+		if err := json.Unmarshal(entry.Update, update); err != nil {
+			return err
+		}
+		update.SetRaw(entry.Update)
+		if originalSpeed && !last.IsZero() {
+			if err := sleep(ctx, entry.Time.Sub(last)); err != nil {
+				return err
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		last = entry.Time
+		executor.ExecuteChain(update)
+	}
+}
+
+/*ReplayFile replays the updates recorded in the given file. See "Replay" for more info.*/
+func ReplayFile(ctx context.Context, path string, executor Executor, originalSpeed bool) error {
+	fl, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer fl.Close()
+	return Replay(ctx, fl, executor, originalSpeed)
+}
+
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
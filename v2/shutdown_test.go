@@ -0,0 +1,87 @@
+package telego_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+func TestShutdownWaitsForHandlers(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	started, release := make(chan struct{}), make(chan struct{})
+	bot.AddHandler("/pay", func(u *objs.Update) {
+		close(started)
+		<-release
+		bot.SendMessage(u.Message.Chat.Id, "paid", "", 0, false, false, nil)
+	}, "private")
+	srv.SendText(10, 10, "/pay")
+	select {
+	case <-started:
+	case <-time.After(srv.Timeout):
+		t.Fatal("the handler was not called")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := bot.Shutdown(ctx); err != context.DeadlineExceeded {
+		t.Fatalf("expected the shutdown to time out while the handler is running, got %v", err)
+	}
+	//Updates are confirmed only after their handlers have returned.
+	if srv.PendingUpdates() != 1 {
+		t.Fatal("the update was confirmed before it's handler returned")
+	}
+	close(release)
+	if err := bot.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.Calls("sendMessage")) != 1 {
+		t.Fatal("shutdown returned before the handler finished")
+	}
+	if pending := srv.PendingUpdates(); pending != 0 {
+		t.Fatalf("received updates were not confirmed, %d updates are pending", pending)
+	}
+	//No updates are received after shutting down.
+	srv.SendText(10, 10, "/pay")
+	srv.ExpectNoCall(t, 100*time.Millisecond, "sendMessage")
+}
+
+func TestShutdownClosesExternalWebhook(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv, func(cfg *configs.BotConfigs) {
+		cfg.Webhook = true
+		cfg.WebHookConfigs = &configs.WebHookConfigs{URL: "https://example.com/hook", ExternalServer: true}
+	})
+	handled := make(chan struct{}, 1)
+	bot.AddHandler("hi", func(u *objs.Update) {
+		handled <- struct{}{}
+	}, "all")
+	post := func() int {
+		req := httptest.NewRequest("POST", "/hook", strings.NewReader(`{"update_id":1,"message":{"message_id":1,"date":0,"chat":{"id":10,"type":"private"},"text":"hi"}}`))
+		req.Header.Set("Content-Type", "application/json")
+		rec := httptest.NewRecorder()
+		bot.WebhookHandler().ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if code := post(); code != 200 {
+		t.Fatalf("webhook responded with %d", code)
+	}
+	<-handled
+	if err := bot.Shutdown(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if code := post(); code != 503 {
+		t.Fatalf("expected 503 after shutting down, got %d", code)
+	}
+	select {
+	case <-handled:
+		t.Fatal("update was handled after shutting down")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -13,7 +13,7 @@ type StickerSet struct {
 	bot                                     *Bot
 	stickerSet                              *objs.StickerSet
 	initStickers                            []*objs.InputSticker
-	initFiles                               []*os.File
+	initFiles                               []*objs.InputFile
 	userId                                  int
 	name, title, stickerFormat, stickerType string
 	needsRepainting                         bool
@@ -118,12 +118,19 @@ If not created yet, then the sticker is added to an internal array. The "Create"
 userId is the user id of the owner.
 */
 func (ss *StickerSet) AddNewStickerByFile(file *os.File, userId int, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
-	stat, err := file.Stat()
-	if err != nil {
-		return false, err
+	if file == nil {
+		return false, errors.New("file is nil")
+	}
+	return ss.AddNewStickerByInputFile(objs.FileFromOSFile(file), userId, emojiList, keywords, maskPosition)
+}
+
+/*AddNewStickerByInputFile works like "AddNewStickerByFile" but takes an InputFile, so the sticker can be a reader, a byte slice, a file path, a file id or a url.*/
+func (ss *StickerSet) AddNewStickerByInputFile(file *objs.InputFile, userId int, emojiList, keywords []string, maskPosition *objs.MaskPosition) (bool, error) {
+	if file == nil {
+		return false, errors.New("file is nil")
 	}
 	inputSticker := &objs.InputSticker{
-		Sticker:      "attach://" + stat.Name(),
+		Sticker:      file.Value(),
 		EmojiList:    emojiList,
 		MaskPosition: maskPosition,
 		KeyWords:     keywords,
@@ -133,10 +140,13 @@ func (ss *StickerSet) AddNewStickerByFile(file *os.File, userId int, emojiList,
 			userId,
 			ss.name,
 			inputSticker,
-			nil,
+			file,
 		)
 		defer ss.update()
-		return res.Ok, err
+		if err != nil {
+			return false, err
+		}
+		return res.Ok, nil
 	}
 	ss.initStickers = append(ss.initStickers, inputSticker)
 	ss.initFiles = append(ss.initFiles, file)
@@ -267,11 +277,21 @@ func (ss *StickerSet) SetThumbByFile(userId int, thumb *os.File) (*objs.Result[b
 	if ss == nil {
 		return nil, errors.New("sticker set is nil")
 	}
-	stats, err := thumb.Stat()
-	if err != nil {
-		return nil, err
+	if thumb == nil {
+		return nil, errors.New("thumb is nil")
+	}
+	return ss.SetThumbByInputFile(userId, objs.FileFromOSFile(thumb))
+}
+
+/*SetThumbByInputFile works like "SetThumbByFile" but takes an InputFile, so the thumbnail can be a reader, a byte slice, a file path, a file id or a url.*/
+func (ss *StickerSet) SetThumbByInputFile(userId int, thumb *objs.InputFile) (*objs.Result[bool], error) {
+	if ss == nil {
+		return nil, errors.New("sticker set is nil")
+	}
+	if thumb == nil {
+		return nil, errors.New("thumb is nil")
 	}
-	return ss.bot.apiInterface.SetStickerSetThumb(ss.stickerSet.Name, "attach://"+stats.Name(), userId, thumb)
+	return ss.bot.apiInterface.SetStickerSetThumb(ss.stickerSet.Name, thumb.Value(), userId, thumb)
 }
 
 // SetTitle changes this sticker set's title.
@@ -2,15 +2,13 @@ package tba
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"net/textproto"
-	"os"
 	"strconv"
 
-	mp "mime/multipart"
-
 	errs "github.com/SakoDroid/telego/v2/errors"
 	objs "github.com/SakoDroid/telego/v2/objects"
 )
@@ -18,80 +16,81 @@ import (
 /*Client used for sending http requests to bot api*/
 type httpSenderClient struct {
 	botApi, apiKey string
+	client         *http.Client
 }
 
-/*This method sends an http request (without processing the response) as application/json. Returns the body of the response.*/
-func (hsc *httpSenderClient) sendHttpReqJson(method string, args objs.MethodArguments) ([]byte, error) {
-	if args == nil {
-		return hsc.sendHttpReq(method, "application/json", make([]byte, 0))
+/*Creates a sender client. If the given http client is nil, a new default client is created. The client is shared by all the requests so the connections are reused.*/
+func newHttpSenderClient(botApi, apiKey string, client *http.Client) *httpSenderClient {
+	if client == nil {
+		client = &http.Client{}
 	}
-	bd := args.ToJson()
-	return hsc.sendHttpReq(method, "application/json", bd)
+	return &httpSenderClient{botApi: botApi, apiKey: apiKey, client: client}
 }
 
-/*This method sends an http request (without processing the response) as multipart/formdata. Returns the body of the response.
-This method is only used for uploading files to bot api server.*/
-func (hsc *httpSenderClient) sendHttpReqMultiPart(method string, args objs.MethodArguments, files ...*os.File) ([]byte, error) {
-	body := &bytes.Buffer{}
-	writer := mp.NewWriter(body)
-	args.ToMultiPart(writer)
-	for _, file := range files {
-		if file == nil {
-			continue
-		}
-		err := hsc.addFileToMultiPartForm(file, writer)
-		if err != nil {
-			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to add file to the multipart form. " + err.Error()}
-		}
+/*This method sends an http request (without processing the response) as application/json. Returns the body of the response.*/
+func (hsc *httpSenderClient) sendHttpReqJson(ctx context.Context, method string, args objs.MethodArguments) ([]byte, error) {
+	if args == nil {
+		return hsc.sendHttpReq(ctx, method, "application/json", http.NoBody, 0)
 	}
-	_ = writer.Close()
-	bts := body.Bytes()
-	return hsc.sendHttpReq(method, writer.FormDataContentType(), bts)
+	bd := args.ToJson()
+	return hsc.sendHttpReq(ctx, method, "application/json", bytes.NewReader(bd), int64(len(bd)))
 }
 
-func (hsc *httpSenderClient) addFileToMultiPartForm(file *os.File, wr *mp.Writer) error {
-	if file != nil {
-		fileStat, err := file.Stat()
-		if err != nil {
-			return err
-		}
-		fw, err2 := wr.CreateFormFile(fileStat.Name(), fileStat.Name())
-		if err2 != nil {
-			return err2
-		}
-		_, err3 := io.Copy(fw, file)
-		if err3 != nil {
-			return err3
-		}
+/*
+This method sends an http request (without processing the response) as multipart/formdata. Returns the body of the response.
+This method is only used for uploading files to bot api server. The files are streamed to the server and are never fully loaded into the memory.
+*/
+func (hsc *httpSenderClient) sendHttpReqMultiPart(ctx context.Context, method string, args objs.MethodArguments, files ...*objs.InputFile) ([]byte, error) {
+	parts, err := openFileParts(files)
+	if err != nil {
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to add file to the multipart form. " + err.Error(), Err: err}
 	}
-	return nil
+	body := newMultiPartBody(args, parts)
+	defer body.Close()
+	return hsc.sendHttpReq(ctx, method, body.contentType, body, body.length)
 }
 
-func (hsc *httpSenderClient) sendHttpReq(method, contetType string, body []byte) ([]byte, error) {
-	cl := http.Client{}
-	req, err := http.NewRequest("POST", hsc.botApi+hsc.apiKey+"/"+method, bytes.NewReader(body))
+/*Sends the request. If contentLength is negative, the length of the body is considered unknown and the body is sent in chunks.*/
+func (hsc *httpSenderClient) sendHttpReq(ctx context.Context, method, contetType string, body io.Reader, contentLength int64) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, "POST", hsc.botApi+hsc.apiKey+"/"+method, body)
 	if err != nil {
 		return nil, err
 	}
 	req.Header.Add(textproto.CanonicalMIMEHeaderKey("content-type"), contetType)
-	req.Header.Add(textproto.CanonicalMIMEHeaderKey("content-length"), strconv.Itoa(len(body)))
-	res, err2 := cl.Do(req)
+	if contentLength >= 0 {
+		req.ContentLength = contentLength
+	} else {
+		req.ContentLength = -1
+	}
+	res, err2 := hsc.client.Do(req)
 	if err2 != nil {
-		return nil, &errs.MethodNotSentError{Method: method, Reason: err2.Error()}
+		//Context errors are returned as is so they can be checked by the caller.
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &errs.MethodNotSentError{Method: method, Reason: err2.Error(), Err: &errs.NetworkError{Err: err2}}
 	}
+	//The body should always be drained and closed, otherwise the connection can not be reused.
+	defer res.Body.Close()
 	if res.StatusCode < 500 {
-		out := make([]byte, res.ContentLength)
-		_, err3 := res.Body.Read(out)
+		out, err3 := io.ReadAll(res.Body)
 		if err3 != nil {
-			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to parse body into byte slice. " + err3.Error()}
+			return nil, &errs.MethodNotSentError{Method: method, Reason: "unable to parse body into byte slice. " + err3.Error(), StatusCode: res.StatusCode, Err: err3}
 		}
 		if res.StatusCode < 300 {
 			return out, nil
 		}
 		fr := &objs.FailureResult{}
 		_ = json.Unmarshal(out, fr)
-		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode), FailureResult: fr}
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode), FailureResult: fr, StatusCode: res.StatusCode, Err: errs.FromFailureResult(fr)}
 	} else {
-		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode)}
+		//The body of 5xx responses is usually not a failure result (for example when a proxy is in front of the api server).
+		out, _ := io.ReadAll(io.LimitReader(res.Body, 1<<16))
+		_, _ = io.Copy(io.Discard, res.Body)
+		fr := &objs.FailureResult{}
+		if json.Unmarshal(out, fr) != nil || fr.ErrorCode == 0 {
+			return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode), StatusCode: res.StatusCode, Err: errs.ErrServerError}
+		}
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "received status code " + strconv.Itoa(res.StatusCode), FailureResult: fr, StatusCode: res.StatusCode, Err: errs.FromFailureResult(fr)}
 	}
 }
@@ -1,10 +1,9 @@
 package tba
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
-	"io"
-	"net/http"
 	"os"
 	"strconv"
 	"strings"
@@ -17,29 +16,50 @@ import (
 	"github.com/SakoDroid/telego/v2/parser"
 )
 
-var interfaceCreated = false
-
 // BotAPIInterface is the interface which connects the telegram bot API to the bot.
 type BotAPIInterface struct {
 	botConfigs           *cfgs.BotConfigs
 	updateRoutineRunning bool
 	updateChannel        *chan *objs.Update
 	chatUpadateChannel   *chan *objs.ChatUpdate
-	updateRoutineChannel chan bool
+	stopUpdateRoutine    context.CancelFunc
+	receiverDone         chan struct{}
+	ctx                  context.Context
 	updateParser         *parser.UpdateParser
 	lastOffset           int
+	offsets              *offsetTracker
 	logger               *logger.BotLogger
+	sender               *httpSenderClient
+	limiter              *rateLimiter
+	webhook              *Webhook
 }
 
 /*StartUpdateRoutine starts the update routine to receive updates from api sever*/
 func (bai *BotAPIInterface) StartUpdateRoutine() error {
+	return bai.StartUpdateRoutineCtx(context.Background())
+}
+
+/*StartUpdateRoutineCtx starts the update routine to receive updates from api sever. The update routine stops as soon as the given context is cancelled or "StopUpdateRoutine" is called. Any pending getUpdates request is aborted when the routine stops.*/
+func (bai *BotAPIInterface) StartUpdateRoutineCtx(ctx context.Context) error {
 	if !bai.botConfigs.Webhook {
 		if bai.updateRoutineRunning {
 			return &errs.UpdateRoutineAlreadyStarted{}
 		}
+		offset, err := bai.offsets.configure(bai.botConfigs.UpdateConfigs)
+		if err != nil {
+			return err
+		}
+		if offset > bai.lastOffset {
+			bai.lastOffset = offset
+		}
+		if bai.botConfigs.UpdateConfigs.AtLeastOnce {
+			bai.updateParser.SetProcessedHook(bai.updateProcessed)
+		}
 		bai.updateRoutineRunning = true
-		bai.updateRoutineChannel = make(chan bool)
-		go bai.startReceiving()
+		routineCtx, cancel := context.WithCancel(ctx)
+		bai.stopUpdateRoutine = cancel
+		bai.receiverDone = make(chan struct{})
+		go bai.startReceiving(routineCtx, bai.receiverDone)
 		return nil
 	} else {
 		return errors.New("webhook option is true")
@@ -50,10 +70,64 @@ func (bai *BotAPIInterface) StartUpdateRoutine() error {
 func (bai *BotAPIInterface) StopUpdateRoutine() {
 	if bai.updateRoutineRunning {
 		bai.updateRoutineRunning = false
-		bai.updateRoutineChannel <- true
+		bai.stopUpdateRoutine()
 	}
 }
 
+/*
+StopUpdateRoutineCtx stops the update routine and waits for it to exit and for the received updates to be processed (see "Wait" method of the update parser).
+Then the updates which have been received are confirmed to the api server, so they are not received again after restarting the bot.
+Returns the context's error if the context is cancelled before that, in this case the updates are not confirmed.
+*/
+func (bai *BotAPIInterface) StopUpdateRoutineCtx(ctx context.Context) error {
+	done := bai.receiverDone
+	bai.StopUpdateRoutine()
+	if done == nil {
+		return nil
+	}
+	select {
+	case <-done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	if err := bai.updateParser.Wait(ctx); err != nil {
+		return err
+	}
+	offset := bai.nextOffset()
+	if offset == 1 {
+		return nil
+	}
+	//Updates are confirmed by calling getUpdates with an offset higher than their ids.
+	args := objs.GetUpdatesArgs{Offset: offset, Limit: 1}
+	if bai.botConfigs.UpdateConfigs != nil {
+		args.AllowedUpdates = bai.botConfigs.UpdateConfigs.AllowedUpdates
+	}
+	res, err := bai.sender.sendHttpReqJson(ctx, "getUpdates", &args)
+	if err != nil {
+		return err
+	}
+	_, err = bai.preParseResult(res, "getUpdates")
+	return err
+}
+
+/*
+WithContext returns a copy of this interface which sends all of it's requests with the given context. Cancelling the context aborts the pending requests and they return the context's error.
+
+The returned interface shares the configs, update parser and http client with the original one and should only be used for calling the api methods.
+*/
+func (bai *BotAPIInterface) WithContext(ctx context.Context) API {
+	out := *bai
+	out.ctx = ctx
+	return &out
+}
+
+func (bai *BotAPIInterface) context() context.Context {
+	if bai.ctx == nil {
+		return context.Background()
+	}
+	return bai.ctx
+}
+
 /*GetUpdateChannel returns the update channel*/
 func (bai *BotAPIInterface) GetUpdateChannel() *chan *objs.Update {
 	return bai.updateChannel
@@ -69,21 +143,23 @@ func (bai *BotAPIInterface) GetUpdateParser() *parser.UpdateParser {
 	return bai.updateParser
 }
 
-func (bai *BotAPIInterface) startReceiving() {
-	cl := httpSenderClient{botApi: bai.botConfigs.BotAPI, apiKey: bai.botConfigs.APIKey}
+func (bai *BotAPIInterface) startReceiving(ctx context.Context, done chan struct{}) {
+	defer close(done)
 loop:
 	for {
-		time.Sleep(bai.botConfigs.UpdateConfigs.UpdateFrequency)
 		select {
-		case <-bai.updateRoutineChannel:
+		case <-ctx.Done():
 			break loop
-		default:
-			args := objs.GetUpdatesArgs{Offset: bai.lastOffset + 1, Limit: bai.botConfigs.UpdateConfigs.Limit, Timeout: bai.botConfigs.UpdateConfigs.Timeout}
+		case <-time.After(bai.botConfigs.UpdateConfigs.UpdateFrequency):
+			args := objs.GetUpdatesArgs{Offset: bai.nextOffset(), Limit: bai.botConfigs.UpdateConfigs.Limit, Timeout: bai.botConfigs.UpdateConfigs.Timeout}
 			if bai.botConfigs.UpdateConfigs.AllowedUpdates != nil {
 				args.AllowedUpdates = bai.botConfigs.UpdateConfigs.AllowedUpdates
 			}
-			res, err := cl.sendHttpReqJson("getUpdates", &args)
+			res, err := bai.sender.sendHttpReqJson(ctx, "getUpdates", &args)
 			if err != nil {
+				if ctx.Err() != nil {
+					break loop
+				}
 				bai.logger.GetRaw().Println("Error receiving updates.", err)
 				continue loop
 			}
@@ -108,6 +184,24 @@ func (bai *BotAPIInterface) parseUpdateresults(body []byte) error {
 	return nil
 }
 
+/*
+Returns the offset which should be used for calling getUpdates. Calling getUpdates with this offset confirms the received updates to the api server. In at least once mode the updates which are
+still being processed are not confirmed, so they are received again until they are processed. These updates are filtered by the offset tracker.
+*/
+func (bai *BotAPIInterface) nextOffset() int {
+	if bai.offsets.atLeastOnce {
+		return bai.offsets.offset() + 1
+	}
+	return bai.lastOffset + 1
+}
+
+/*Called after the middleware chain and the handlers of each update return, when at least once mode is enabled.*/
+func (bai *BotAPIInterface) updateProcessed(up *objs.Update) {
+	if err := bai.offsets.done(up.Update_id); err != nil {
+		bai.logger.GetRaw().Println("Error saving the offset of the updates.", err)
+	}
+}
+
 // ParseUpdate parses the received update and returns the last update offset.
 func (bai *BotAPIInterface) ParseUpdate(body []byte) (int, error) {
 	def := &objs.Result[json.RawMessage]{}
@@ -118,22 +212,50 @@ func (bai *BotAPIInterface) ParseUpdate(body []byte) (int, error) {
 	if !def.Ok {
 		return 0, &errs.MethodNotSentError{Method: "getUpdates", Reason: "server returned false for \"ok\" field."}
 	}
-	ur := &objs.Result[[]*objs.Update]{}
-	err := json.Unmarshal(body, ur)
+	updates, err := decodeUpdates(body, bai.botConfigs.KeepRawUpdates)
 	if err != nil {
 		return 0, err
 	}
 
 	lastOffset := 0
-	for _, val := range ur.Result {
+	for _, val := range updates {
 		if val.Update_id > lastOffset {
 			lastOffset = val.Update_id
 		}
-		go bai.updateParser.ExecuteChain(val)
+	}
+	//Updates which have already been received are skipped.
+	fresh, err := bai.offsets.receive(updates)
+	if err != nil {
+		bai.logger.GetRaw().Println("Error saving the offset of the updates.", err)
+	}
+	for _, val := range fresh {
+		bai.updateParser.ExecuteChainAsync(val)
 	}
 	return lastOffset, nil
 }
 
+/*Decodes the updates of a getUpdates response. If "keepRaw" is true, the raw json of every update is attached to it.*/
+func decodeUpdates(body []byte, keepRaw bool) ([]*objs.Update, error) {
+	if !keepRaw {
+		ur := &objs.Result[[]*objs.Update]{}
+		err := json.Unmarshal(body, ur)
+		return ur.Result, err
+	}
+	rr := &objs.Result[[]json.RawMessage]{}
+	if err := json.Unmarshal(body, rr); err != nil {
+		return nil, err
+	}
+	out := make([]*objs.Update, len(rr.Result))
+	for i, raw := range rr.Result {
+		out[i] = &objs.Update{}
+		if err := json.Unmarshal(raw, out[i]); err != nil {
+			return nil, err
+		}
+		out[i].SetRaw(raw)
+	}
+	return out, nil
+}
+
 func (bai *BotAPIInterface) isChatIdOk(chatIdInt int, chatIdString string) bool {
 	if chatIdInt == 0 {
 		return chatIdString != ""
@@ -156,6 +278,34 @@ func (bai *BotAPIInterface) GetMe() (*objs.Result[*objs.User], error) {
 	return msg, nil
 }
 
+/*LogOut logs out the bot from the cloud bot api server. It should be called before running the bot locally.*/
+func (bai *BotAPIInterface) LogOut() (*objs.Result[bool], error) {
+	res, err := bai.SendCustom("logOut", nil, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	msg := &objs.Result[bool]{}
+	err3 := json.Unmarshal(res, msg)
+	if err3 != nil {
+		return nil, err3
+	}
+	return msg, nil
+}
+
+/*Close closes the bot instance before moving it from one local server to another.*/
+func (bai *BotAPIInterface) Close() (*objs.Result[bool], error) {
+	res, err := bai.SendCustom("close", nil, false, nil)
+	if err != nil {
+		return nil, err
+	}
+	msg := &objs.Result[bool]{}
+	err3 := json.Unmarshal(res, msg)
+	if err3 != nil {
+		return nil, err3
+	}
+	return msg, nil
+}
+
 /*
 SendMessage sends a message to the user. chatIdInt is used for all chats but channles and chatidString is used for channels (in form of @channleusername) and only of them has be populated, otherwise ChatIdProblem error will be returned.
 "chatId" and "text" arguments are required. other arguments are optional for bot api.
@@ -225,7 +375,7 @@ func (bai *BotAPIInterface) ForwardMessage(chatIdInt, fromChatIdInt int, chatIdS
 SendPhoto sends a photo (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "photo" arguments are required. other arguments are optional for bot api.
 */
-func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string, photoFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string, photoFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -244,9 +394,9 @@ func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string,
 		var res []byte
 		var err error
 		if photoFile != nil {
-			res, err = bai.SendCustom("sendPhoto", args, true, photoFile, nil)
+			res, err = bai.sendFiles("sendPhoto", args, true, photoFile, nil)
 		} else {
-			res, err = bai.SendCustom("sendPhoto", args, false, nil, nil)
+			res, err = bai.sendFiles("sendPhoto", args, false, nil, nil)
 		}
 		if err != nil {
 			return nil, err
@@ -266,7 +416,7 @@ func (bai *BotAPIInterface) SendPhoto(chatIdInt int, chatIdString, photo string,
 SendVideo sends a video (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "video" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string, videoFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string, videoFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -285,7 +435,7 @@ func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string,
 			SupportsStreaming: supportsStreaming,
 			HasSpoiler:        hasSpoiler,
 		}
-		res, err := bai.SendCustom("sendVideo", args, true, videoFile, thumbFile)
+		res, err := bai.sendFiles("sendVideo", args, true, videoFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -304,7 +454,7 @@ func (bai *BotAPIInterface) SendVideo(chatIdInt int, chatIdString, video string,
 SendAudio sends an audio (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "audio" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0,to ignore string arguments pass "")
 */
-func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string, audioFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string, audioFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -323,7 +473,7 @@ func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string,
 			Performer:       performer,
 			Title:           title,
 		}
-		res, err := bai.SendCustom("sendAudio", args, true, audioFile, thumbFile)
+		res, err := bai.sendFiles("sendAudio", args, true, audioFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -342,7 +492,7 @@ func (bai *BotAPIInterface) SendAudio(chatIdInt int, chatIdString, audio string,
 sSendDocument sends a document (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "document" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document string, documentFile *os.File, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document string, documentFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -359,7 +509,7 @@ func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document s
 			CaptionEntities:             captionEntities,
 			DisableContentTypeDetection: DisableContentTypeDetection,
 		}
-		res, err := bai.SendCustom("sendDocument", args, true, documentFile, thumbFile)
+		res, err := bai.sendFiles("sendDocument", args, true, documentFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -378,7 +528,7 @@ func (bai *BotAPIInterface) SendDocument(chatIdInt int, chatIdString, document s
 SendAnimation sends an animation (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "animation" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation string, animationFile *os.File, caption, parseMode string, width, height, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation string, animationFile *objs.InputFile, caption, parseMode string, width, height, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -398,7 +548,7 @@ func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation
 			Duration:        duration,
 			HasSpoiler:      hasSpoiler,
 		}
-		res, err := bai.SendCustom("sendAnimation", args, true, animationFile, thumbFile)
+		res, err := bai.sendFiles("sendAnimation", args, true, animationFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -417,7 +567,7 @@ func (bai *BotAPIInterface) SendAnimation(chatIdInt int, chatIdString, animation
 sSendVoice sends a voice (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "voice" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string, voiceFile *os.File, caption, parseMode string, duration int, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string, voiceFile *objs.InputFile, caption, parseMode string, duration int, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -433,7 +583,7 @@ func (bai *BotAPIInterface) SendVoice(chatIdInt int, chatIdString, voice string,
 			CaptionEntities: captionEntities,
 			Duration:        duration,
 		}
-		res, err := bai.SendCustom("sendVoice", args, true, voiceFile)
+		res, err := bai.sendFiles("sendVoice", args, true, voiceFile)
 		if err != nil {
 			return nil, err
 		}
@@ -453,7 +603,7 @@ SendVideoNote sends a video note (file,url,telegramId) to a channel (chatIdStrin
 "chatId" and "videoNote" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 Note that sending video note by URL is not supported by telegram.
 */
-func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote string, videoNoteFile *os.File, caption, parseMode string, length, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *os.File, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote string, videoNoteFile *objs.InputFile, caption, parseMode string, length, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -471,7 +621,7 @@ func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote
 			Length:          length,
 			Duration:        duration,
 		}
-		res, err := bai.SendCustom("sendVideoNote", args, true, videoNoteFile, thumbFile)
+		res, err := bai.sendFiles("sendVideoNote", args, true, videoNoteFile, thumbFile)
 		if err != nil {
 			return nil, err
 		}
@@ -490,7 +640,7 @@ func (bai *BotAPIInterface) SendVideoNote(chatIdInt int, chatIdString, videoNote
 SendMediaGroup sends an album of media (file,url,telegramId) to a channel (chatIdString) or a chat (chatIdInt)
 "chatId" and "media" arguments are required. other arguments are optional for bot api. (to ignore int arguments, pass 0)
 */
-func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, reply_to_message_id, messageThreadId int, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...*os.File) (*objs.Result[[]objs.Message], error) {
+func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, reply_to_message_id, messageThreadId int, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...*objs.InputFile) (*objs.Result[[]objs.Message], error) {
 	if chatIdInt != 0 && chatIdString != "" {
 		return nil, &errs.ChatIdProblem{}
 	}
@@ -502,7 +652,7 @@ func (bai *BotAPIInterface) SendMediaGroup(chatIdInt int, chatIdString string, r
 			),
 			Media: media,
 		}
-		res, err := bai.SendCustom("sendMediaGroup", args, true, files...)
+		res, err := bai.sendFiles("sendMediaGroup", args, true, files...)
 		if err != nil {
 			return nil, err
 		}
@@ -828,11 +978,6 @@ DownloadFile downloads a file from telegram servers and saves it into the given
 This method closes the given file. If the file is nil, this method will create a file based on the name of the file stored in telegram servers.
 */
 func (bai *BotAPIInterface) DownloadFile(fileObject *objs.File, file *os.File) error {
-	url := "https://api.telegram.org/file/bot" + bai.botConfigs.APIKey + "/" + fileObject.FilePath
-	res, err := http.Get(url)
-	if err != nil {
-		return err
-	}
 	if file == nil {
 		ar := strings.Split(fileObject.FilePath, "/")
 		name := ar[len(ar)-1]
@@ -842,16 +987,13 @@ func (bai *BotAPIInterface) DownloadFile(fileObject *objs.File, file *os.File) e
 			return er
 		}
 	}
-	if res.StatusCode < 300 {
-		_, err2 := io.Copy(file, res.Body)
-		if err2 != nil {
-			return err2
-		}
-		err3 := file.Close()
-		return err3
-	} else {
-		return &errs.MethodNotSentError{Method: "getFile", Reason: "server returned status code " + strconv.Itoa(res.StatusCode)}
+	_, err2 := bai.DownloadTo(fileObject, file, 0)
+	if err2 != nil {
+		file.Close()
+		return err2
 	}
+	err3 := file.Close()
+	return err3
 }
 
 /*BanChatMember bans a chat member*/
@@ -1170,15 +1312,14 @@ func (bai *BotAPIInterface) DeclineChatJoinRequest(chatIdInt int, chatIdString s
 }
 
 /*SetChatPhoto sets the chat photo to given file.*/
-func (bai *BotAPIInterface) SetChatPhoto(chatIdInt int, chatIdString string, file *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetChatPhoto(chatIdInt int, chatIdString string, file *objs.InputFile) (*objs.Result[bool], error) {
 	args := &objs.SetChatPhotoArgs{}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	stats, er := file.Stat()
-	if er != nil {
-		return nil, er
+	if file == nil {
+		return nil, &errs.RequiredArgumentError{ArgName: "file", MethodName: "setChatPhoto"}
 	}
-	args.Photo = "attach://" + stats.Name()
-	res, err := bai.SendCustom("setChatPhoto", args, true, file)
+	args.Photo = file.Value()
+	res, err := bai.sendFiles("setChatPhoto", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1541,7 +1682,7 @@ func (bai *BotAPIInterface) EditMessageCaption(chatIdInt int, chatIdString strin
 }
 
 /*EditMessageMedia edits the media of the given message in the given chat.*/
-func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...*os.File) (*objs.Result[json.RawMessage], error) {
+func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...*objs.InputFile) (*objs.Result[json.RawMessage], error) {
 	args := &objs.EditMessageMediaArgs{
 		EditMessageDefaultArgs: objs.EditMessageDefaultArgs{
 			MessageId:       messageId,
@@ -1551,7 +1692,7 @@ func (bai *BotAPIInterface) EditMessageMedia(chatIdInt int, chatIdString string,
 		Media: media,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("editMessageMedia", args, true, file...)
+	res, err := bai.sendFiles("editMessageMedia", args, true, file...)
 	if err != nil {
 		return nil, err
 	}
@@ -1623,7 +1764,7 @@ func (bai *BotAPIInterface) DeleteMessage(chatIdInt int, chatIdString string, me
 }
 
 /*SendSticker sends an sticker to the given chat id.*/
-func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker, emoji string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo, messageThreadId int, replyMarkup objs.ReplyMarkup, file *os.File) (*objs.Result[*objs.Message], error) {
+func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker, emoji string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo, messageThreadId int, replyMarkup objs.ReplyMarkup, file *objs.InputFile) (*objs.Result[*objs.Message], error) {
 	args := &objs.SendStickerArgs{
 		DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{
 			DisableNotification:      disableNotif,
@@ -1637,7 +1778,7 @@ func (bai *BotAPIInterface) SendSticker(chatIdInt int, chatIdString, sticker, em
 		Emoji:   emoji,
 	}
 	args.ChatId = bai.fixChatId(chatIdInt, chatIdString)
-	res, err := bai.SendCustom("sendSticker", args, true, file)
+	res, err := bai.sendFiles("sendSticker", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1667,13 +1808,13 @@ func (bai *BotAPIInterface) GetStickerSet(name string) (*objs.Result[*objs.Stick
 }
 
 /*UploadStickerFile uploads the given file as an sticker on the telegram servers.*/
-func (bai *BotAPIInterface) UploadStickerFile(userId int, stickerFormat string, sticker *objs.InputSticker, file *os.File) (*objs.Result[*objs.File], error) {
+func (bai *BotAPIInterface) UploadStickerFile(userId int, stickerFormat string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[*objs.File], error) {
 	args := &objs.UploadStickerFileArgs{
 		UserId:        userId,
 		Sticker:       sticker,
 		StickerFormat: stickerFormat,
 	}
-	res, err := bai.SendCustom("uploadStickerFile", args, true, file)
+	res, err := bai.sendFiles("uploadStickerFile", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1686,7 +1827,7 @@ func (bai *BotAPIInterface) UploadStickerFile(userId int, stickerFormat string,
 }
 
 /*CreateNewStickerSet creates a new sticker set with the given arguments*/
-func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, StickerFormat, StickerType string, needsRepainting bool, stickers []*objs.InputSticker, files ...*os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, StickerFormat, StickerType string, needsRepainting bool, stickers []*objs.InputSticker, files ...*objs.InputFile) (*objs.Result[bool], error) {
 	args := &objs.CreateNewStickerSetArgs{
 		UserId:          userId,
 		Name:            name,
@@ -1696,7 +1837,7 @@ func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, Sticker
 		StickerType:     StickerType,
 		NeedsRepainting: needsRepainting,
 	}
-	res, err := bai.SendCustom("createNewStickerSet", args, true, files...)
+	res, err := bai.sendFiles("createNewStickerSet", args, true, files...)
 	if err != nil {
 		return nil, err
 	}
@@ -1709,13 +1850,13 @@ func (bai *BotAPIInterface) CreateNewStickerSet(userId int, name, title, Sticker
 }
 
 /*AddStickerToSet adds a new sticker to the given set.*/
-func (bai *BotAPIInterface) AddStickerToSet(userId int, name string, sticker *objs.InputSticker, file *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) AddStickerToSet(userId int, name string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[bool], error) {
 	args := &objs.AddStickerSetArgs{
 		UserId:  userId,
 		Name:    name,
 		Sticker: sticker,
 	}
-	res, err := bai.SendCustom("addStickerToSet", args, true, file)
+	res, err := bai.sendFiles("addStickerToSet", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -1763,13 +1904,13 @@ func (bai *BotAPIInterface) DeleteStickerFromSet(sticker string) (*objs.Result[b
 }
 
 /*SetStickerSetThumb sets the thumbnail for the given sticker*/
-func (bai *BotAPIInterface) SetStickerSetThumb(name, thumb string, userId int, file *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetStickerSetThumb(name, thumb string, userId int, file *objs.InputFile) (*objs.Result[bool], error) {
 	args := &objs.SetStickerSetThumbnailArgs{
 		Name:   name,
 		Thumb:  thumb,
 		UserId: userId,
 	}
-	res, err := bai.SendCustom("setStickerSetThumb", args, true, file)
+	res, err := bai.sendFiles("setStickerSetThumb", args, true, file)
 	if err != nil {
 		return nil, err
 	}
@@ -2154,7 +2295,7 @@ func (bai *BotAPIInterface) GetWebhookInfo() (*objs.Result[*objs.WebhookInfo], e
 }
 
 /*SetWebhook sets a webhook for the bot.*/
-func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile *os.File) (*objs.Result[bool], error) {
+func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile *objs.InputFile) (*objs.Result[bool], error) {
 	args := objs.SetWebhookArgs{
 		URL:                url,
 		IPAddress:          ip,
@@ -2163,13 +2304,9 @@ func (bai *BotAPIInterface) SetWebhook(url, ip string, maxCnc int, allowedUpdate
 		DropPendingUpdates: dropPendingUpdates,
 	}
 	if keyFile != nil {
-		stat, errs := keyFile.Stat()
-		if errs != nil {
-			return nil, errs
-		}
-		args.Certificate = "attach://" + stat.Name()
+		args.Certificate = keyFile.Value()
 	}
-	res, err := bai.SendCustom("setWebhook", &args, keyFile != nil, keyFile)
+	res, err := bai.sendFiles("setWebhook", &args, keyFile != nil, keyFile)
 	if err != nil {
 		return nil, err
 	}
@@ -2714,14 +2851,48 @@ func (bai *BotAPIInterface) GetMyName(languageCode string) (*objs.Result[*objs.B
 
 /*SendCustom calls the given method on api server with the given arguments. "MP" options indicates that the request should be made in multipart/formdata form. If this method sends a file to the api server the "MP" option should be true*/
 func (bai *BotAPIInterface) SendCustom(methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error) {
+	return bai.SendCustomCtx(bai.context(), methodName, args, MP, files...)
+}
+
+/*SendCustomCtx works like "SendCustom" but the request is sent with the given context. If the context is cancelled or it's deadline exceeds before the response is received, the context's error is returned.*/
+func (bai *BotAPIInterface) SendCustomCtx(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error) {
+	inputFiles := make([]*objs.InputFile, len(files))
+	for i, file := range files {
+		inputFiles[i] = objs.FileFromOSFile(file)
+	}
+	return bai.SendCustomFiles(ctx, methodName, args, MP, inputFiles...)
+}
+
+/*SendCustomFiles works like "SendCustomCtx" but the files are given as InputFile, so they can be readers, byte slices or file paths. Files that do not need to be uploaded (file ids and urls) are ignored.*/
+func (bai *BotAPIInterface) SendCustomFiles(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*objs.InputFile) ([]byte, error) {
+	res, err := bai.sendCustom(ctx, methodName, args, MP, files...)
+	if err != nil && bai.botConfigs.RetryConfigs != nil {
+		return bai.retry(ctx, err, methodName, args, MP, files...)
+	}
+	return res, err
+}
+
+func (bai *BotAPIInterface) sendFiles(methodName string, args objs.MethodArguments, MP bool, files ...*objs.InputFile) ([]byte, error) {
+	return bai.SendCustomFiles(bai.context(), methodName, args, MP, files...)
+}
+
+func (bai *BotAPIInterface) sendCustom(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*objs.InputFile) ([]byte, error) {
+	if bai.limiter != nil {
+		if err := bai.limiter.wait(ctx, args); err != nil {
+			return nil, err
+		}
+	}
 	start := time.Now().UnixMicro()
-	cl := httpSenderClient{botApi: bai.botConfigs.BotAPI, apiKey: bai.botConfigs.APIKey}
+	if !MP && bai.webhook != nil && bai.webhook.captureReply(methodName, args, webhookReplyUpdate(ctx)) {
+		bai.logger.Log(methodName, "\t\t\t", "Replied", strconv.FormatInt((time.Now().UnixMicro()-start), 10)+"µs", logger.BOLD+logger.OKBLUE, logger.OKGREEN, "")
+		return bai.preParseResult(replyResultOf(methodName), methodName)
+	}
 	var res []byte
 	var err2 error
 	if MP {
-		res, err2 = cl.sendHttpReqMultiPart(methodName, args, files...)
+		res, err2 = bai.sender.sendHttpReqMultiPart(ctx, methodName, args, files...)
 	} else {
-		res, err2 = cl.sendHttpReqJson(methodName, args)
+		res, err2 = bai.sender.sendHttpReqJson(ctx, methodName, args)
 	}
 	done := time.Now().UnixMicro()
 	if err2 != nil {
@@ -2757,7 +2928,7 @@ func (bai *BotAPIInterface) preParseResult(res []byte, method string) ([]byte, e
 		if err != nil {
 			return nil, err
 		}
-		return nil, &errs.MethodNotSentError{Method: method, Reason: "server returned false ok filed", FailureResult: fr}
+		return nil, &errs.MethodNotSentError{Method: method, Reason: "server returned false ok filed", FailureResult: fr, Err: errs.FromFailureResult(fr)}
 	}
 	return res, nil
 }
@@ -2775,15 +2946,16 @@ func (bai *BotAPIInterface) fixChatId(chatIdInt int, chatIdString string) []byte
 	}
 }
 
+/*UseWebhookReplies lets the interface send the api calls in the response of the requests received by the given webhook. See "ReplyInResponse" field of the webhook configs.*/
+func (bai *BotAPIInterface) UseWebhookReplies(w *Webhook) {
+	bai.webhook = w
+}
+
 /*
 CreateInterface returns an iterface to communicate with the bot api.
 If the updateFrequency argument is not nil, the update routine begins automtically
 */
 func CreateInterface(botCfg *cfgs.BotConfigs, botLogger *logger.BotLogger) (*BotAPIInterface, error) {
-	if interfaceCreated {
-		return nil, &errs.BotInterfaceAlreadyCreated{}
-	}
-	interfaceCreated = true
 	ch := make(chan *objs.Update)
 	ch3 := make(chan *objs.ChatUpdate)
 	temp := &BotAPIInterface{
@@ -2792,6 +2964,11 @@ func CreateInterface(botCfg *cfgs.BotConfigs, botLogger *logger.BotLogger) (*Bot
 		chatUpadateChannel: &ch3,
 		updateParser:       parser.CreateUpdateParser(&ch, &ch3, botCfg, botLogger),
 		logger:             botLogger,
+		offsets:            newOffsetTracker(),
+		sender:             newHttpSenderClient(botCfg.BotAPI, botCfg.APIKey, botCfg.HttpClient),
+	}
+	if botCfg.RateLimitConfigs != nil {
+		temp.limiter = newRateLimiter(botCfg.RateLimitConfigs)
 	}
 	return temp, nil
 }
@@ -0,0 +1,22 @@
+package tba
+
+import "testing"
+
+func TestDecodeUpdatesKeepsRaw(t *testing.T) {
+	raw := `{"update_id":3,"message":{"message_id":1,"date":0,"chat":{"id":5,"type":"private"},"text":"hi"},"unknown_field":1}`
+	body := []byte(`{"ok":true,"result":[` + raw + `]}`)
+	updates, err := decodeUpdates(body, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 1 || updates[0].Update_id != 3 || updates[0].Raw() != nil {
+		t.Fatal("raw json should not be kept by default")
+	}
+	updates, err = decodeUpdates(body, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(updates) != 1 || updates[0].Message.Text != "hi" || string(updates[0].Raw()) != raw {
+		t.Fatalf("raw json has not been kept : %s", updates[0].Raw())
+	}
+}
@@ -1,10 +1,17 @@
 package tba
 
 import (
+	"context"
+	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"io"
+	"net"
 	"net/http"
+	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 
 	cfg "github.com/SakoDroid/telego/v2/configs"
 	log "github.com/SakoDroid/telego/v2/logger"
@@ -12,34 +19,186 @@ import (
 	up "github.com/SakoDroid/telego/v2/parser"
 )
 
+/*
+Webhook receives the updates sent by the api server. Webhook is an http.Handler, so it can be mounted on any http server (for example behind a reverse proxy which terminates TLS),
+or it can run it's own server using "StartWebHook" method.
+*/
 type Webhook struct {
-	configs          *cfg.BotConfigs
-	isSecretTokenSet bool
-	parser           *up.UpdateParser
-	Logger           *log.BotLogger
+	configs *cfg.BotConfigs
+	parser  *up.UpdateParser
+	Logger  *log.BotLogger
+	/*OnCertRenewal is called after the self signed certificate of the webhook is renewed. It should upload the new certificate using setWebhook method.*/
+	OnCertRenewal func() error
+	mx            sync.Mutex
+	server        *http.Server
+	certs         *certManager
+	stopRenewal   chan struct{}
+	replies       []*webhookReply
+	//closed is true after "Shutdown" is called. Closed webhooks reject the requests with 503 status code, so the api server sends the updates again later.
+	closed bool
+	//requests counts the requests which are being handled.
+	requests sync.WaitGroup
 }
 
-// StartWebHook starts the webhook.
+/*NewWebhook creates a webhook which passes the received updates to the given parser. The returned webhook can be used as an http.Handler without calling "StartWebHook".*/
+func NewWebhook(cfg *cfg.BotConfigs, parser *up.UpdateParser, logger *log.BotLogger) *Webhook {
+	return &Webhook{
+		configs: cfg,
+		parser:  parser,
+		Logger:  logger,
+	}
+}
+
+// StartWebHook starts the webhook server. Errors which happen while starting the server (such as invalid certificates or a port which is already in use) are returned.
 func (w *Webhook) StartWebHook(cfg *cfg.BotConfigs, parser *up.UpdateParser) error {
 	w.configs = cfg
-	// interfaceUpdateChannel = iuc
-	// chatUpdateChannel = cuc
-	w.isSecretTokenSet = cfg.WebHookConfigs.SecretToken != ""
 	w.parser = parser
-	w.startTheServer()
-	return nil
+	w.Open()
+	return w.startTheServer()
+}
+
+/*Open lets the webhook accept requests again after it has been shut down. "StartWebHook" calls this method, so it's only needed when the webhook is mounted on an external server.*/
+func (w *Webhook) Open() {
+	w.mx.Lock()
+	w.closed = false
+	w.mx.Unlock()
 }
 
-func (w *Webhook) startTheServer() {
+func (w *Webhook) startTheServer() error {
+	whc := w.configs.WebHookConfigs
 	mux := http.NewServeMux()
 	mux.HandleFunc("/", w.mainHandler)
-	mux.HandleFunc("/"+w.configs.APIKey, w.handleReq)
+	mux.Handle(w.path(), w)
+	var tlsConfig *tls.Config
+	if !whc.PlainHTTP {
+		var err error
+		tlsConfig, err = w.createTLSConfig()
+		if err != nil {
+			return err
+		}
+	}
+	ln, err := net.Listen("tcp", ":"+strconv.Itoa(whc.Port))
+	if err != nil {
+		return err
+	}
+	if tlsConfig != nil {
+		ln = tls.NewListener(ln, tlsConfig)
+	}
+	srv := &http.Server{Handler: mux, TLSConfig: tlsConfig}
+	w.mx.Lock()
+	w.server = srv
+	if w.certs != nil && w.stopRenewal == nil {
+		w.stopRenewal = make(chan struct{})
+		go w.certs.startRenewalRoutine(w.stopRenewal, w.OnCertRenewal, func(err error) {
+			w.Logger.GetRaw().Println("Webhook : Failed to renew the certificate.", err)
+		})
+	}
+	w.mx.Unlock()
 	go func() {
-		err := http.ListenAndServeTLS(":"+strconv.Itoa(w.configs.WebHookConfigs.Port), w.configs.WebHookConfigs.CertFile, w.configs.WebHookConfigs.KeyFile, mux)
+		err := srv.Serve(ln)
+		if err != nil && err != http.ErrServerClosed {
+			w.Logger.GetRaw().Println("Webhook : The server stopped.", err)
+		}
+	}()
+	return nil
+}
+
+/*Returns the tls config of the server. The certificate and key files are added to the certificates of the config given in the configs.*/
+func (w *Webhook) createTLSConfig() (*tls.Config, error) {
+	whc := w.configs.WebHookConfigs
+	tlsConfig := &tls.Config{}
+	if whc.TLSConfig != nil {
+		tlsConfig = whc.TLSConfig.Clone()
+	}
+	if w.certs != nil {
+		tlsConfig.GetCertificate = w.certs.getCertificate
+	} else if whc.CertFile != "" && whc.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(whc.CertFile, whc.KeyFile)
 		if err != nil {
-			w.Logger.GetRaw().Fatalln("Webhook : Failed to start the HTTPS server.", err)
+			return nil, err
 		}
+		tlsConfig.Certificates = append(tlsConfig.Certificates, cert)
+	}
+	if len(tlsConfig.Certificates) == 0 && tlsConfig.GetCertificate == nil && tlsConfig.GetConfigForClient == nil {
+		return nil, errors.New("webhook : no certificate is provided for the https server")
+	}
+	return tlsConfig, nil
+}
+
+/*The path of the webhook url, which the api server sends the updates to.*/
+func (w *Webhook) path() string {
+	u, err := url.Parse(w.configs.WebHookConfigs.URL)
+	if err != nil || u.Path == "" {
+		return "/" + w.configs.APIKey
+	}
+	return u.Path
+}
+
+/*
+LoadCertificate loads the self signed certificate of the webhook if "SelfSigned" field of the webhook configs is true. If the certificate files don't exist or the certificate is about to expire,
+a new certificate is generated for the host of the webhook url and saved in the files. Returns true if a new certificate has been generated, which should be uploaded using setWebhook method.
+
+The certificate is renewed automatically before it expires while the server started by "StartWebHook" is running.
+*/
+func (w *Webhook) LoadCertificate() (bool, error) {
+	if !w.configs.WebHookConfigs.SelfSigned {
+		return false, nil
+	}
+	certs, created, err := loadCertManager(w.configs.WebHookConfigs)
+	if err != nil {
+		return false, err
+	}
+	w.mx.Lock()
+	w.certs = certs
+	w.mx.Unlock()
+	return created, nil
+}
+
+/*
+Shutdown stops accepting new requests and waits for the requests which are being handled to pass their updates to the update parser. The server started by "StartWebHook" (if any) is also shut down gracefully, see http.Server.Shutdown for more info.
+After calling this method, the webhook responds to the requests with 503 status code until "Open" or "StartWebHook" is called.
+*/
+func (w *Webhook) Shutdown(ctx context.Context) error {
+	w.mx.Lock()
+	w.closed = true
+	srv := w.server
+	w.server = nil
+	if w.stopRenewal != nil {
+		close(w.stopRenewal)
+		w.stopRenewal = nil
+	}
+	w.mx.Unlock()
+	var err error
+	if srv != nil {
+		err = srv.Shutdown(ctx)
+	}
+	done := make(chan struct{})
+	go func() {
+		w.requests.Wait()
+		close(done)
 	}()
+	select {
+	case <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+/*ServeHTTP handles the requests sent by the api server. The request path is not checked, so the webhook can be mounted at any path.*/
+func (w *Webhook) ServeHTTP(wr http.ResponseWriter, req *http.Request) {
+	//The request is counted while the lock is held, so "Shutdown" can't miss it.
+	w.mx.Lock()
+	if w.closed {
+		w.mx.Unlock()
+		wr.WriteHeader(503)
+		wr.Write([]byte{})
+		return
+	}
+	w.requests.Add(1)
+	w.mx.Unlock()
+	defer w.requests.Done()
+	w.handleReq(wr, req)
 }
 
 func (w *Webhook) mainHandler(wr http.ResponseWriter, req *http.Request) {
@@ -48,9 +207,9 @@ func (w *Webhook) mainHandler(wr http.ResponseWriter, req *http.Request) {
 }
 
 func (w *Webhook) handleReq(wr http.ResponseWriter, req *http.Request) {
-	if w.isSecretTokenSet {
+	if secretToken := w.configs.WebHookConfigs.SecretToken; secretToken != "" {
 		token := req.Header.Get("X-Telegram-Bot-Api-Secret-Token")
-		if token != w.configs.WebHookConfigs.SecretToken {
+		if token != secretToken {
 			wr.WriteHeader(403)
 			wr.Write([]byte{})
 			return
@@ -58,15 +217,23 @@ func (w *Webhook) handleReq(wr http.ResponseWriter, req *http.Request) {
 	}
 	contentType := req.Header.Get("Content-Type")
 	if contentType != "" && strings.HasSuffix(contentType, "json") {
-		if req.ContentLength > 0 {
-			body := make([]byte, req.ContentLength)
-			_, err := req.Body.Read(body)
+		//The content length is unknown (-1) when the body is chunked, which may happen behind reverse proxies.
+		if req.ContentLength != 0 {
+			body, err := io.ReadAll(req.Body)
 			if err == nil {
 				update := &objs.Update{}
 				jsonErr := json.Unmarshal(body, update)
 				if jsonErr == nil {
-					// up.ParseSingleUpdate(update, interfaceUpdateChannel, chatUpdateChannel, configs)
-					w.parser.ExecuteChain(update)
+					if w.configs.KeepRawUpdates {
+						update.SetRaw(body)
+					}
+					if w.configs.WebHookConfigs.ReplyInResponse {
+						if w.executeAndReply(wr, update) {
+							return
+						}
+					} else {
+						w.parser.Dispatch(update)
+					}
 				} else {
 					w.Logger.GetRaw().Println("Webhook : Error parsing the update. Address :", req.RemoteAddr, ". Error :", jsonErr)
 				}
@@ -85,6 +252,27 @@ func (w *Webhook) handleReq(wr http.ResponseWriter, req *http.Request) {
 	}
 }
 
+/*
+Executes the middleware chain for the update and writes the first api call which is sent for the update (using "WithWebhookReply") in the response. Returns false if no api call is sent
+before the handlers of the update return or the timeout is reached.
+*/
+func (w *Webhook) executeAndReply(wr http.ResponseWriter, update *objs.Update) bool {
+	reply := w.newReply(update)
+	w.parser.DispatchAndNotify(update, func() { close(reply.processed) })
+	timeout := w.configs.WebHookConfigs.ReplyTimeout
+	if timeout <= 0 {
+		timeout = defaultReplyTimeout
+	}
+	body := w.waitReply(reply, timeout)
+	if body == nil {
+		return false
+	}
+	wr.Header().Set("Content-Type", "application/json")
+	wr.WriteHeader(200)
+	wr.Write(body)
+	return true
+}
+
 func (w *Webhook) send400(wr *http.ResponseWriter, reason string) {
 	(*wr).Header().Add("Content-Type", "text/plain")
 	(*wr).Header().Add("Content-Length", strconv.Itoa(len(reason)))
@@ -0,0 +1,151 @@
+package tba
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/parser"
+)
+
+/*
+API is the interface which connects the bot to the bot api server. BotAPIInterface is the default implementation of it.
+
+Any implementation of this interface can be passed to "telego.NewBotWithAPI", so the code which uses the bot can be tested against a fake implementation (see "tbamock" package).
+*/
+type API interface {
+	//Receiving updates
+	StartUpdateRoutine() error
+	StartUpdateRoutineCtx(ctx context.Context) error
+	StopUpdateRoutine()
+	StopUpdateRoutineCtx(ctx context.Context) error
+	WithContext(ctx context.Context) API
+	GetUpdateChannel() *chan *objs.Update
+	GetChatUpdateChannel() *chan *objs.ChatUpdate
+	GetUpdateParser() *parser.UpdateParser
+	ParseUpdate(body []byte) (int, error)
+
+	//Bot api methods
+	GetMe() (*objs.Result[*objs.User], error)
+	LogOut() (*objs.Result[bool], error)
+	Close() (*objs.Result[bool], error)
+	SendMessage(chatIdInt int, chatIdString, text, parseMode string, entities []objs.MessageEntity, linkPreviewOptions *objs.LinkPreviewOptions, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_to_message_id, messageThreadId int, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	ForwardMessage(chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, disableNotif, ProtectContent bool, messageId, messageThreadId int) (*objs.Result[*objs.Message], error)
+	SendPhoto(chatIdInt int, chatIdString, photo string, photoFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error)
+	SendVideo(chatIdInt int, chatIdString, video string, videoFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendAudio(chatIdInt int, chatIdString, audio string, audioFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer, title string, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendDocument(chatIdInt int, chatIdString, document string, documentFile *objs.InputFile, caption, parseMode string, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendAnimation(chatIdInt int, chatIdString, animation string, animationFile *objs.InputFile, caption, parseMode string, width, height, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendVoice(chatIdInt int, chatIdString, voice string, voiceFile *objs.InputFile, caption, parseMode string, duration int, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendVideoNote(chatIdInt int, chatIdString, videoNote string, videoNoteFile *objs.InputFile, caption, parseMode string, length, duration int, reply_to_message_id, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification, allow_sending_without_reply, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendMediaGroup(chatIdInt int, chatIdString string, reply_to_message_id, messageThreadId int, media []objs.InputMedia, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...*objs.InputFile) (*objs.Result[[]objs.Message], error)
+	SendLocation(chatIdInt int, chatIdString string, latitude, longitude, horizontalAccuracy float32, livePeriod, heading, proximityAlertRadius, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	EditMessageLiveLocation(chatIdInt int, chatIdString, inlineMessageId string, messageId int, latitude, longitude, horizontalAccuracy float32, heading, proximityAlertRadius int, reply_markup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	StopMessageLiveLocation(chatIdInt int, chatIdString, inlineMessageId string, messageId int, replyMarkup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	SendVenue(chatIdInt int, chatIdString string, latitude, longitude float32, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendContact(chatIdInt int, chatIdString, phoneNumber, firstName, lastName, vCard string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendPoll(chatIdInt int, chatIdString, question string, options []string, isClosed, isAnonymous bool, pollType string, allowMultipleAnswers bool, correctOptionIndex int, explanation, explanationParseMode string, explanationEntities []objs.MessageEntity, openPeriod, closeDate int, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendDice(chatIdInt int, chatIdString, emoji string, reply_to_message_id, messageThreadId int, disable_notification, allow_sending_without_reply, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendChatAction(chatIdInt, messageThreadId int, chatIdString, chatAction string) (*objs.Result[*objs.Message], error)
+	GetUserProfilePhotos(userId, offset, limit int) (*objs.Result[*objs.UserProfilePhotos], error)
+	GetFile(fileId string) (*objs.Result[*objs.File], error)
+	DownloadFile(fileObject *objs.File, file *os.File) error
+	BanChatMember(chatIdInt int, chatIdString string, userId, untilDate int, revokeMessages bool) (*objs.Result[bool], error)
+	UnbanChatMember(chatIdInt int, chatIdString string, userId int, onlyIfBanned bool) (*objs.Result[bool], error)
+	RestrictChatMember(chatIdInt int, chatIdString string, userId int, permissions objs.ChatPermissions, useIndependentChatPermissions bool, untilDate int) (*objs.Result[bool], error)
+	PromoteChatMember(chatIdInt int, chatIdString string, userId int, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics bool) (*objs.Result[bool], error)
+	SetMyDefaultAdministratorRights(forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages bool) (*objs.Result[bool], error)
+	GetMyDefaultAdministratorRights(forChannels bool) (*objs.Result[*objs.ChatAdministratorRights], error)
+	SetChatAdministratorCustomTitle(chatIdInt int, chatIdString string, userId int, customTitle string) (*objs.Result[bool], error)
+	BanOrUnbanChatSenderChat(chatIdInt int, chatIdString string, senderChatId int, ban bool) (*objs.Result[bool], error)
+	SetChatPermissions(chatIdInt int, chatIdString string, useIndependentChatPermissions bool, permissions objs.ChatPermissions) (*objs.Result[bool], error)
+	ExportChatInviteLink(chatIdInt int, chatIdString string) (*objs.Result[string], error)
+	CreateChatInviteLink(chatIdInt int, chatIdString, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error)
+	EditChatInviteLink(chatIdInt int, chatIdString, inviteLink, name string, expireDate, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error)
+	RevokeChatInviteLink(chatIdInt int, chatIdString, inviteLink string) (*objs.Result[*objs.ChatInviteLink], error)
+	ApproveChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error)
+	DeclineChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error)
+	SetChatPhoto(chatIdInt int, chatIdString string, file *objs.InputFile) (*objs.Result[bool], error)
+	DeleteChatPhoto(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	SetChatTitle(chatIdInt int, chatIdString, title string) (*objs.Result[bool], error)
+	SetChatDescription(chatIdInt int, chatIdString, descriptions string) (*objs.Result[bool], error)
+	PinChatMessage(chatIdInt int, chatIdString string, messageId int, disableNotification bool) (*objs.Result[bool], error)
+	UnpinChatMessage(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error)
+	UnpinAllChatMessages(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	LeaveChat(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	GetChat(chatIdInt int, chatIdString string) (*objs.Result[*objs.Chat], error)
+	GetChatAdministrators(chatIdInt int, chatIdString string) (*objs.Result[[]objs.ChatMemberOwner], error)
+	GetChatMemberCount(chatIdInt int, chatIdString string) (*objs.Result[int], error)
+	GetChatMember(chatIdInt int, chatIdString string, userId int) (*objs.Result[json.RawMessage], error)
+	SetChatStickerSet(chatIdInt int, chatIdString, stickerSetName string) (*objs.Result[bool], error)
+	DeleteChatStickerSet(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	AnswerCallbackQuery(callbackQueryId, text, url string, showAlert bool, CacheTime int) (*objs.Result[bool], error)
+	SetMyCommands(commands []objs.BotCommand, scope objs.BotCommandScope, languageCode string) (*objs.Result[bool], error)
+	DeleteMyCommands(scope objs.BotCommandScope, languageCode string) (*objs.Result[bool], error)
+	GetMyCommands(scope objs.BotCommandScope, languageCode string) (*objs.Result[[]objs.BotCommand], error)
+	EditMessageText(chatIdInt int, chatIdString string, messageId int, inlineMessageId, text, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	EditMessageCaption(chatIdInt int, chatIdString string, messageId int, inlineMessageId, caption, parseMode string, captionEntities []objs.MessageEntity, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	EditMessageMedia(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...*objs.InputFile) (*objs.Result[json.RawMessage], error)
+	EditMessagereplyMarkup(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	StopPoll(chatIdInt int, chatIdString string, messageId int, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[*objs.Poll], error)
+	DeleteMessage(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error)
+	SendSticker(chatIdInt int, chatIdString, sticker, emoji string, disableNotif, allowSendingWithoutreply, protectContent bool, replyTo, messageThreadId int, replyMarkup objs.ReplyMarkup, file *objs.InputFile) (*objs.Result[*objs.Message], error)
+	GetStickerSet(name string) (*objs.Result[*objs.StickerSet], error)
+	UploadStickerFile(userId int, stickerFormat string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[*objs.File], error)
+	CreateNewStickerSet(userId int, name, title, StickerFormat, StickerType string, needsRepainting bool, stickers []*objs.InputSticker, files ...*objs.InputFile) (*objs.Result[bool], error)
+	AddStickerToSet(userId int, name string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[bool], error)
+	SetStickerPositionInSet(sticker string, position int) (*objs.Result[bool], error)
+	DeleteStickerFromSet(sticker string) (*objs.Result[bool], error)
+	SetStickerSetThumb(name, thumb string, userId int, file *objs.InputFile) (*objs.Result[bool], error)
+	DeleteStickerSet(name string) (*objs.Result[bool], error)
+	SetStickerSetTitle(name, title string) (*objs.Result[bool], error)
+	SetStickerEmojiList(sticker string, emojiLst []string) (*objs.Result[bool], error)
+	SetStickerKeywords(sticker string, keywords []string) (*objs.Result[bool], error)
+	SetStickerMaskPosition(sticker string, maskPosition *objs.MaskPosition) (*objs.Result[bool], error)
+	AnswerInlineQuery(inlineQueryId string, results []objs.InlineQueryResult, cacheTime int, isPersonal bool, nextOffset string, button *objs.InlineQueryResultsButton) (*objs.Result[bool], error)
+	SendInvoice(chatIdInt int, chatIdString, title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif bool, replyToMessageId, messageThreadId int, allowSendingWithoutReply bool, replyMarkup objs.InlineKeyboardMarkup) (*objs.Result[*objs.Message], error)
+	CreateInvoiceLink(title, description, payload, providerToken, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, providerData, photoURL string, photoSize, photoWidth, photoHeight int, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible bool) (*objs.Result[string], error)
+	AnswerShippingQuery(shippingQueryId string, ok bool, shippingOptions []objs.ShippingOption, errorMessage string) (*objs.Result[bool], error)
+	AnswerPreCheckoutQuery(preCheckoutQueryId string, ok bool, errorMessage string) (*objs.Result[bool], error)
+	CopyMessage(chatIdInt, fromChatIdInt int, chatIdString, fromChatIdString string, messageId int, disableNotif bool, caption, parseMode string, replyTo int, allowSendingWihtoutReply, ProtectContent bool, replyMarkUp objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error)
+	SetPassportDataErrors(userId int, errors []objs.PassportElementError) (*objs.Result[bool], error)
+	SendGame(chatId int, gameShortName string, disableNotif bool, replyTo int, allowSendingWithoutReply bool, replyMarkup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SetGameScore(userId, score int, force, disableEditMessage bool, chatId, messageId int, inlineMessageId string) (*objs.Result[json.RawMessage], error)
+	GetGameHighScores(userId, chatId, messageId int, inlineMessageId string) (*objs.Result[[]*objs.GameHighScore], error)
+	GetWebhookInfo() (*objs.Result[*objs.WebhookInfo], error)
+	SetWebhook(url, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile *objs.InputFile) (*objs.Result[bool], error)
+	DeleteWebhook(dropPendingUpdates bool) (*objs.Result[bool], error)
+	GetCustomEmojiStickers(customEmojiIds []string) (*objs.Result[[]*objs.Sticker], error)
+	AnswerWebAppQuery(webAppQueryId string, result objs.InlineQueryResult) (*objs.SentWebAppMessage, error)
+	GetChatMenuButton(chatId int64) (*objs.Result[*objs.MenuButton], error)
+	SetChatMenuButton(chatId int64, menuButton *objs.MenuButton) (*objs.Result[bool], error)
+	GetForumTopicIconStickers() (*objs.Result[[]*objs.Sticker], error)
+	CreateForumTopic(chatIdInt int, chatIdString, name, iconCustomEmojiId string, iconColor int) (*objs.Result[*objs.ForumTopic], error)
+	EditForumTopic(chatIdInt int, chatIdString, name, iconCustomEmojiId string, messageThreadId int) (*objs.Result[bool], error)
+	CloseForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	ReopenForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	DeleteForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	UnpinAllForumTopicMessages(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	EditGeneralForumTopic(chatIdInt int, chatIdString, name string) (*objs.Result[bool], error)
+	CloseGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	ReopenGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	HideGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	UnhideGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	UnpinAllGeneralForumTopicMessages(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	SetMyDescription(description, languageCode string) (*objs.Result[bool], error)
+	SetMyShortDescription(description, languageCode string) (*objs.Result[bool], error)
+	GetMyDescription(languageCode string) (*objs.Result[*objs.BotDescription], error)
+	GetMyShortDescription(languageCode string) (*objs.Result[*objs.BotShortDescription], error)
+	SetMyName(name, languageCode string) (*objs.Result[bool], error)
+	GetMyName(languageCode string) (*objs.Result[*objs.BotName], error)
+
+	//Custom requests and downloads
+	SendCustom(methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error)
+	SendCustomCtx(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error)
+	SendCustomFiles(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*objs.InputFile) ([]byte, error)
+	DownloadTo(fileObject *objs.File, w io.Writer, maxSize int64) (int64, error)
+}
+
+var _ API = &BotAPIInterface{}
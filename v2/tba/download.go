@@ -0,0 +1,98 @@
+package tba
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	errs "github.com/SakoDroid/telego/v2/errors"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+DownloadTo downloads the given file and writes it's content into the given writer. Returns the number of bytes written.
+
+"maxSize" is the maximum allowed size of the file in bytes. If it's 0, the maximum size in the download configs of the bot is used.
+If the file is bigger than the maximum size, FileTooLargeError is returned and at most "maxSize" bytes are written.
+*/
+func (bai *BotAPIInterface) DownloadTo(fileObject *objs.File, w io.Writer, maxSize int64) (int64, error) {
+	dc := bai.botConfigs.DownloadConfigs
+	if maxSize <= 0 && dc != nil {
+		maxSize = dc.MaxSize
+	}
+	if maxSize > 0 && fileObject.FileSize > maxSize {
+		return 0, &errs.FileTooLargeError{FileId: fileObject.FileId, MaxSize: maxSize}
+	}
+	body, err := bai.openRemoteFile(fileObject)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+	var reader io.Reader = body
+	if maxSize > 0 {
+		reader = io.LimitReader(body, maxSize)
+	}
+	if dc != nil && dc.Progress != nil {
+		w = &progressWriter{w: w, fileId: fileObject.FileId, total: fileObject.FileSize, progress: dc.Progress}
+	}
+	n, err := io.Copy(w, reader)
+	if err != nil {
+		return n, err
+	}
+	if maxSize > 0 && n == maxSize {
+		if _, err := io.ReadFull(body, make([]byte, 1)); err == nil {
+			return n, &errs.FileTooLargeError{FileId: fileObject.FileId, MaxSize: maxSize}
+		}
+	}
+	if dc != nil && dc.VerifySize && fileObject.FileSize != 0 && n != fileObject.FileSize {
+		return n, &errs.FileSizeMismatchError{FileId: fileObject.FileId, Expected: fileObject.FileSize, Actual: n}
+	}
+	return n, nil
+}
+
+/*
+Opens the content of the given file. If the bot is in local mode and the file path is absolute, the file is read directly from the file system.
+Otherwise the file is downloaded from the file endpoint of the bot api server.
+*/
+func (bai *BotAPIInterface) openRemoteFile(fileObject *objs.File) (io.ReadCloser, error) {
+	if bai.botConfigs.LocalMode && filepath.IsAbs(fileObject.FilePath) {
+		return os.Open(fileObject.FilePath)
+	}
+	req, err := http.NewRequestWithContext(bai.context(), "GET", bai.botConfigs.FileAPI()+bai.botConfigs.APIKey+"/"+fileObject.FilePath, nil)
+	if err != nil {
+		return nil, err
+	}
+	res, err := bai.sender.client.Do(req)
+	if err != nil {
+		if ctxErr := req.Context().Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
+		return nil, &errs.MethodNotSentError{Method: "getFile", Reason: err.Error(), Err: &errs.NetworkError{Err: err}}
+	}
+	if res.StatusCode >= 300 {
+		res.Body.Close()
+		mnse := &errs.MethodNotSentError{Method: "getFile", Reason: "server returned status code " + strconv.Itoa(res.StatusCode), StatusCode: res.StatusCode}
+		if res.StatusCode >= 500 {
+			mnse.Err = errs.ErrServerError
+		}
+		return nil, mnse
+	}
+	return res.Body, nil
+}
+
+/*A writer which reports the number of bytes written to it.*/
+type progressWriter struct {
+	w              io.Writer
+	fileId         string
+	written, total int64
+	progress       func(fileId string, downloaded, total int64)
+}
+
+func (pw *progressWriter) Write(p []byte) (int, error) {
+	n, err := pw.w.Write(p)
+	pw.written += int64(n)
+	pw.progress(pw.fileId, pw.written, pw.total)
+	return n, err
+}
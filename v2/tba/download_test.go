@@ -0,0 +1,105 @@
+package tba
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	errs "github.com/SakoDroid/telego/v2/errors"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func TestDownloadFromFileAPI(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/file/bottoken/photos/file_1.jpg" {
+			t.Error("wrong download path :", r.URL.Path)
+		}
+		w.Write([]byte("content"))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	path := filepath.Join(t.TempDir(), "out.jpg")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bai.DownloadFile(&objs.File{FilePath: "photos/file_1.jpg"}, file); err != nil {
+		t.Fatal(err)
+	}
+	bt, _ := os.ReadFile(path)
+	if string(bt) != "content" {
+		t.Error("wrong downloaded content :", string(bt))
+	}
+}
+
+func TestDownloadInLocalMode(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request should be sent in local mode")
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	bai.botConfigs.LocalMode = true
+	dir := t.TempDir()
+	serverFile := filepath.Join(dir, "file_1.jpg")
+	if err := os.WriteFile(serverFile, []byte("local content"), 0666); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "out.jpg")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bai.DownloadFile(&objs.File{FilePath: serverFile}, file); err != nil {
+		t.Fatal(err)
+	}
+	bt, _ := os.ReadFile(path)
+	if string(bt) != "local content" {
+		t.Error("wrong downloaded content :", string(bt))
+	}
+}
+
+func TestDownloadToWithLimits(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("0123456789"))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	var lastProgress int64
+	bai.botConfigs.DownloadConfigs = &cfgs.DownloadConfigs{
+		VerifySize: true,
+		Progress: func(fileId string, downloaded, total int64) {
+			lastProgress = downloaded
+		},
+	}
+	buf := &bytes.Buffer{}
+	n, err := bai.DownloadTo(&objs.File{FileId: "f", FilePath: "f", FileSize: 10}, buf, 0)
+	if err != nil || n != 10 || buf.String() != "0123456789" {
+		t.Error("unexpected result :", n, err, buf.String())
+	}
+	if lastProgress != 10 {
+		t.Error("progress was not reported :", lastProgress)
+	}
+	var ftle *errs.FileTooLargeError
+	buf.Reset()
+	_, err = bai.DownloadTo(&objs.File{FileId: "f", FilePath: "f"}, buf, 5)
+	if !errors.As(err, &ftle) {
+		t.Error("expected FileTooLargeError, got :", err)
+	}
+	if buf.Len() > 5 {
+		t.Error("more than the maximum size has been written :", buf.Len())
+	}
+	_, err = bai.DownloadTo(&objs.File{FileId: "f", FilePath: "f", FileSize: 100}, buf, 10)
+	if !errors.As(err, &ftle) {
+		t.Error("expected FileTooLargeError based on the reported size, got :", err)
+	}
+	var fsme *errs.FileSizeMismatchError
+	_, err = bai.DownloadTo(&objs.File{FileId: "f", FilePath: "f", FileSize: 8}, buf, 0)
+	if !errors.As(err, &fsme) {
+		t.Error("expected FileSizeMismatchError, got :", err)
+	}
+}
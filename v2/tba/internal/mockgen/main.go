@@ -0,0 +1,191 @@
+/*
+mockgen generates the recording mock of tba.API interface in tbamock package. It should be run again every time the interface changes :
+
+	go generate ./tba/tbamock
+*/
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/*Methods which are written by hand in tbamock package.*/
+var skipped = map[string]bool{
+	"WithContext":          true,
+	"GetUpdateChannel":     true,
+	"GetChatUpdateChannel": true,
+	"GetUpdateParser":      true,
+}
+
+/*Import paths of the packages that may be used in the method signatures.*/
+var knownImports = map[string]string{
+	"context": `"context"`,
+	"json":    `"encoding/json"`,
+	"io":      `"io"`,
+	"os":      `"os"`,
+	"objs":    `objs "github.com/SakoDroid/telego/v2/objects"`,
+}
+
+type param struct {
+	name, typ string
+	variadic  bool
+}
+
+func main() {
+	in := flag.String("in", "../api.go", "the file which contains API interface")
+	out := flag.String("out", "api_mock.go", "the output file")
+	flag.Parse()
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, *in, nil, 0)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	iface := findInterface(file, "API")
+	if iface == nil {
+		log.Fatalln("API interface not found in", *in)
+	}
+	fields, methods := &bytes.Buffer{}, &bytes.Buffer{}
+	for _, m := range iface.Methods.List {
+		if len(m.Names) == 0 || skipped[m.Names[0].Name] {
+			continue
+		}
+		name := m.Names[0].Name
+		ft := m.Type.(*ast.FuncType)
+		params := getParams(fset, ft.Params)
+		results := getParams(fset, ft.Results)
+		writeMethod(fields, methods, name, params, results)
+	}
+	body := fields.String() + "}\n" + methods.String()
+	src := &bytes.Buffer{}
+	src.WriteString("// Code generated by mockgen. DO NOT EDIT.\n\npackage tbamock\n\nimport (\n")
+	for _, imp := range usedImports(body) {
+		src.WriteString("\t" + imp + "\n")
+	}
+	src.WriteString(")\n\n// funcs contains the functions which are called by the methods of API. If a function is nil, the method returns the default result.\ntype funcs struct {\n")
+	src.WriteString(body)
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		log.Fatalln("generated code is invalid :", err)
+	}
+	if err := os.WriteFile(*out, formatted, 0666); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+func findInterface(file *ast.File, name string) *ast.InterfaceType {
+	for _, decl := range file.Decls {
+		gd, ok := decl.(*ast.GenDecl)
+		if !ok {
+			continue
+		}
+		for _, spec := range gd.Specs {
+			ts, ok := spec.(*ast.TypeSpec)
+			if ok && ts.Name.Name == name {
+				iface, _ := ts.Type.(*ast.InterfaceType)
+				return iface
+			}
+		}
+	}
+	return nil
+}
+
+func getParams(fset *token.FileSet, list *ast.FieldList) []param {
+	var out []param
+	if list == nil {
+		return out
+	}
+	for _, field := range list.List {
+		typ, variadic := field.Type, false
+		if el, ok := typ.(*ast.Ellipsis); ok {
+			typ, variadic = el.Elt, true
+		}
+		buf := &bytes.Buffer{}
+		printer.Fprint(buf, fset, typ)
+		if len(field.Names) == 0 {
+			out = append(out, param{name: fmt.Sprintf("p%d", len(out)), typ: buf.String(), variadic: variadic})
+		}
+		for _, n := range field.Names {
+			out = append(out, param{name: n.Name, typ: buf.String(), variadic: variadic})
+		}
+	}
+	return out
+}
+
+func writeMethod(fields, methods *bytes.Buffer, name string, params, results []param) {
+	var decl, args, call []string
+	for _, p := range params {
+		if p.variadic {
+			decl = append(decl, p.name+" ..."+p.typ)
+			call = append(call, p.name+"...")
+		} else {
+			decl = append(decl, p.name+" "+p.typ)
+			call = append(call, p.name)
+		}
+		args = append(args, p.name)
+	}
+	var resTypes, defaults []string
+	for _, r := range results {
+		resTypes = append(resTypes, r.typ)
+		defaults = append(defaults, defaultValue(r.typ))
+	}
+	res := strings.Join(resTypes, ", ")
+	if len(resTypes) > 1 {
+		res = "(" + res + ")"
+	}
+	sig := "(" + strings.Join(decl, ", ") + ") " + res
+	fmt.Fprintf(fields, "\t%sFunc func%s\n", name, sig)
+	fmt.Fprintf(methods, "\n// %s records the call and calls %sFunc if it is set.\n", name, name)
+	fmt.Fprintf(methods, "func (m *API) %s%s {\n", name, sig)
+	fmt.Fprintf(methods, "\tm.record(%s)\n", strings.Join(append([]string{`"` + name + `"`}, args...), ", "))
+	ret := "return "
+	if len(results) == 0 {
+		ret = ""
+	}
+	fmt.Fprintf(methods, "\tif m.%sFunc != nil {\n\t\t%sm.%sFunc(%s)\n", name, ret, name, strings.Join(call, ", "))
+	if len(results) == 0 {
+		fmt.Fprintf(methods, "\t\treturn\n\t}\n}\n")
+		return
+	}
+	fmt.Fprintf(methods, "\t}\n\treturn %s\n}\n", strings.Join(defaults, ", "))
+}
+
+/*Successful results are returned by default, so the code which uses the results does not panic.*/
+func defaultValue(typ string) string {
+	switch {
+	case strings.HasPrefix(typ, "*objs.Result["):
+		return "&" + typ[1:] + "{Ok: true}"
+	case typ == "error" || strings.HasPrefix(typ, "*") || strings.HasPrefix(typ, "[]") || strings.HasPrefix(typ, "map["):
+		return "nil"
+	case typ == "bool":
+		return "false"
+	case typ == "string":
+		return `""`
+	case typ == "int" || typ == "int64":
+		return "0"
+	default:
+		return "*new(" + typ + ")"
+	}
+}
+
+func usedImports(src string) []string {
+	var out []string
+	for name, imp := range knownImports {
+		if regexp.MustCompile(`\b` + name + `\.`).MatchString(src) {
+			out = append(out, imp)
+		}
+	}
+	sort.Strings(out)
+	return out
+}
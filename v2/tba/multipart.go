@@ -0,0 +1,112 @@
+package tba
+
+import (
+	"io"
+
+	mp "mime/multipart"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*A file that should be uploaded in a multipart form. Size is -1 if the size of the file is not known.*/
+type filePart struct {
+	name   string
+	reader io.ReadCloser
+	size   int64
+}
+
+/*Opens the given files for uploading. Files that do not need to be uploaded are skipped. If opening any of the files fails, the opened files are closed.*/
+func openFileParts(files []*objs.InputFile) ([]*filePart, error) {
+	parts := make([]*filePart, 0, len(files))
+	for _, file := range files {
+		if file == nil || !file.NeedsUpload() {
+			continue
+		}
+		reader, size, err := file.Open()
+		if err != nil {
+			closeFileParts(parts)
+			return nil, err
+		}
+		parts = append(parts, &filePart{name: file.Name(), reader: reader, size: size})
+	}
+	return parts, nil
+}
+
+func closeFileParts(parts []*filePart) {
+	for _, part := range parts {
+		_ = part.reader.Close()
+	}
+}
+
+/*
+multiPartBody is a multipart/form-data request body which is written to the request while it's being sent, using a pipe.
+This way the files are streamed and the memory usage does not depend on the size of the files.
+*/
+type multiPartBody struct {
+	*io.PipeReader
+	contentType string
+	length      int64
+}
+
+func newMultiPartBody(args objs.MethodArguments, parts []*filePart) *multiPartBody {
+	pr, pw := io.Pipe()
+	writer := mp.NewWriter(pw)
+	body := &multiPartBody{
+		PipeReader:  pr,
+		contentType: writer.FormDataContentType(),
+		length:      computeMultiPartLength(writer.Boundary(), args, parts),
+	}
+	go func() {
+		defer closeFileParts(parts)
+		pw.CloseWithError(writeMultiPart(writer, args, parts))
+	}()
+	return body
+}
+
+func writeMultiPart(writer *mp.Writer, args objs.MethodArguments, parts []*filePart) error {
+	if args != nil {
+		args.ToMultiPart(writer)
+	}
+	for _, part := range parts {
+		fw, err := writer.CreateFormFile(part.name, part.name)
+		if err != nil {
+			return err
+		}
+		if _, err = io.Copy(fw, part.reader); err != nil {
+			return err
+		}
+	}
+	return writer.Close()
+}
+
+/*Computes the length of the multipart body without reading the files. Returns -1 if size of any of the files is unknown.*/
+func computeMultiPartLength(boundary string, args objs.MethodArguments, parts []*filePart) int64 {
+	counter := &countingWriter{}
+	writer := mp.NewWriter(counter)
+	_ = writer.SetBoundary(boundary)
+	if args != nil {
+		args.ToMultiPart(writer)
+	}
+	for _, part := range parts {
+		if part.size < 0 {
+			return -1
+		}
+		if _, err := writer.CreateFormFile(part.name, part.name); err != nil {
+			return -1
+		}
+		counter.n += part.size
+	}
+	if err := writer.Close(); err != nil {
+		return -1
+	}
+	return counter.n
+}
+
+type countingWriter struct {
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	cw.n += int64(len(p))
+	return len(p), nil
+}
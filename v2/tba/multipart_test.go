@@ -0,0 +1,92 @@
+package tba
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func TestMultiPartStreaming(t *testing.T) {
+	content := strings.Repeat("telego", 100000)
+	path := filepath.Join(t.TempDir(), "test.txt")
+	if err := os.WriteFile(path, []byte(content), 0666); err != nil {
+		t.Fatal(err)
+	}
+	file, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bd, _ := io.ReadAll(r.Body)
+		if r.ContentLength != int64(len(bd)) {
+			t.Error("wrong content length. header :", r.ContentLength, ", actual :", len(bd))
+		}
+		r.Body = io.NopCloser(strings.NewReader(string(bd)))
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Error(err)
+		}
+		fl, _, err := r.FormFile("test.txt")
+		if err != nil {
+			t.Fatal(err)
+		}
+		received, _ := io.ReadAll(fl)
+		if string(received) != content {
+			t.Error("received file is not the same as the sent file")
+		}
+		if r.FormValue("chat_id") != "123" {
+			t.Error("wrong chat id :", r.FormValue("chat_id"))
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	args := &objs.SendDocumentArgs{Document: "attach://test.txt"}
+	args.SetChatId(123)
+	if _, err := bai.SendCustom("sendDocument", args, true, file); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMultiPartInputFiles(t *testing.T) {
+	contents := map[string]string{
+		"bytes.txt":  "uploaded from a byte slice",
+		"reader.txt": "uploaded from a reader",
+	}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		for name, content := range contents {
+			fl, _, err := r.FormFile(name)
+			if err != nil {
+				t.Fatal(err)
+			}
+			received, _ := io.ReadAll(fl)
+			if string(received) != content {
+				t.Error("wrong content for", name, ":", string(received))
+			}
+		}
+		w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	doc := objs.FileFromBytes("bytes.txt", []byte(contents["bytes.txt"]))
+	thumb := objs.FileFromReader("reader.txt", io.MultiReader(strings.NewReader(contents["reader.txt"])))
+	if doc.Value() != "attach://bytes.txt" {
+		t.Error("wrong value for the document :", doc.Value())
+	}
+	_, err := bai.SendDocument(123, "", doc.Value(), doc, "", "", 0, 0, thumb.Value(), thumb, false, false, false, nil, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if thumb.Reusable() {
+		t.Error("a reader which is not a seeker should not be reusable after being read")
+	}
+}
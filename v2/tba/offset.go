@@ -0,0 +1,131 @@
+package tba
+
+import (
+	"sync"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+offsetTracker keeps the offset of the updates received by polling and saves it in the offset store. It also filters the updates which have already been received.
+
+In at least once mode the offset is held before the oldest update which is still being processed, so the api server sends the unfinished updates again after a crash or restart.
+The updates which are sent again while the bot is running are filtered by their ids.
+*/
+type offsetTracker struct {
+	mx          sync.Mutex
+	store       cfgs.OffsetStore
+	atLeastOnce bool
+	//All the updates with ids lower than or equal to received have been received.
+	received int
+	//The ids of the updates which have been received but not processed yet, in at least once mode.
+	pending map[int]bool
+	//saveMx serializes the writes to the store. It's always locked before mx.
+	saveMx sync.Mutex
+	saved  int
+}
+
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{pending: make(map[int]bool)}
+}
+
+/*Sets the store and the mode of the tracker and loads the saved offset. Returns the loaded offset.*/
+func (ot *offsetTracker) configure(uc *cfgs.UpdateConfigs) (int, error) {
+	store := uc.OffsetStore
+	if store == nil && uc.OffsetFile != "" {
+		store = cfgs.NewFileOffsetStore(uc.OffsetFile)
+	}
+	offset := 0
+	if store != nil {
+		var err error
+		offset, err = store.Load()
+		if err != nil {
+			return 0, err
+		}
+	}
+	ot.saveMx.Lock()
+	defer ot.saveMx.Unlock()
+	ot.saved = offset
+	ot.mx.Lock()
+	defer ot.mx.Unlock()
+	ot.store = store
+	ot.atLeastOnce = uc.AtLeastOnce
+	if offset > ot.received {
+		ot.received = offset
+	}
+	return ot.received, nil
+}
+
+/*
+Returns the offset which can be confirmed to the api server. It's the id of the last received update, or in at least once mode the id before the oldest update which is still being processed.
+*/
+func (ot *offsetTracker) offset() int {
+	ot.mx.Lock()
+	defer ot.mx.Unlock()
+	return ot.confirmed()
+}
+
+/*Returns the offset which can be confirmed. mx must be held.*/
+func (ot *offsetTracker) confirmed() int {
+	offset := ot.received
+	for id := range ot.pending {
+		if id <= offset {
+			offset = id - 1
+		}
+	}
+	return offset
+}
+
+/*Registers the received updates and returns the ones which have not been received before. In at least once mode, the returned updates are pending until "done" is called for them.*/
+func (ot *offsetTracker) receive(updates []*objs.Update) ([]*objs.Update, error) {
+	ot.saveMx.Lock()
+	defer ot.saveMx.Unlock()
+	ot.mx.Lock()
+	fresh := make([]*objs.Update, 0, len(updates))
+	for _, update := range updates {
+		if update.Update_id <= ot.received {
+			continue
+		}
+		fresh = append(fresh, update)
+	}
+	for _, update := range fresh {
+		if update.Update_id > ot.received {
+			ot.received = update.Update_id
+		}
+		if ot.atLeastOnce {
+			ot.pending[update.Update_id] = true
+		}
+	}
+	ot.mx.Unlock()
+	return fresh, ot.save()
+}
+
+/*Removes the update from the pending updates after it has been processed, which may advance the offset.*/
+func (ot *offsetTracker) done(id int) error {
+	ot.saveMx.Lock()
+	defer ot.saveMx.Unlock()
+	ot.mx.Lock()
+	_, ok := ot.pending[id]
+	delete(ot.pending, id)
+	ot.mx.Unlock()
+	if !ok {
+		return nil
+	}
+	return ot.save()
+}
+
+/*Saves the offset in the store if it has advanced since the last save. saveMx must be held.*/
+func (ot *offsetTracker) save() error {
+	ot.mx.Lock()
+	store, offset := ot.store, ot.confirmed()
+	ot.mx.Unlock()
+	if store == nil || offset <= ot.saved {
+		return nil
+	}
+	if err := store.Save(offset); err != nil {
+		return err
+	}
+	ot.saved = offset
+	return nil
+}
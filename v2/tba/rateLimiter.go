@@ -0,0 +1,182 @@
+package tba
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+// chatIdGetter is implemented by the arguments of the methods which send a message to a chat.
+type chatIdGetter interface {
+	GetChatId() json.RawMessage
+}
+
+/*A token bucket. Tokens can be reserved in advance, so the bucket can go below zero and the caller should wait until the reserved token becomes available.*/
+type bucket struct {
+	capacity, tokens, rate float64
+	last                   time.Time
+}
+
+func newBucket(capacity int, per time.Duration, now time.Time) *bucket {
+	return &bucket{
+		capacity: float64(capacity),
+		tokens:   float64(capacity),
+		rate:     float64(capacity) / per.Seconds(),
+		last:     now,
+	}
+}
+
+/*Reserves a token and returns the time that should be waited before using it.*/
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+/*Gives back a reserved token that has not been used.*/
+func (b *bucket) cancel() {
+	b.tokens++
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if now.After(b.last) {
+		b.tokens += now.Sub(b.last).Seconds() * b.rate
+		if b.tokens > b.capacity {
+			b.tokens = b.capacity
+		}
+		b.last = now
+	}
+}
+
+/*
+rateLimiter delays the outgoing messages so they don't exceed the limits of the api server.
+There is one global bucket for all the chats and one bucket for each chat.
+*/
+type rateLimiter struct {
+	mu                   sync.Mutex
+	cfg                  *cfgs.RateLimitConfigs
+	global               *bucket
+	chats                map[string]*bucket
+	reservesSinceCleanup int
+}
+
+func newRateLimiter(cfg *cfgs.RateLimitConfigs) *rateLimiter {
+	rl := &rateLimiter{cfg: cfg, chats: make(map[string]*bucket)}
+	if cfg.GlobalPerSecond > 0 {
+		rl.global = newBucket(cfg.GlobalPerSecond, time.Second, time.Now())
+	}
+	return rl
+}
+
+/*Waits until the given method can be sent. Only the methods that send a message to a chat are limited. If the context is done before that, the context's error is returned.*/
+func (rl *rateLimiter) wait(ctx context.Context, args objs.MethodArguments) error {
+	getter, ok := args.(chatIdGetter)
+	if !ok {
+		return nil
+	}
+	chatId := string(getter.GetChatId())
+	if chatId == "" {
+		return nil
+	}
+	chat, wait := rl.reserveChat(chatId, time.Now())
+	if err := rl.sleep(ctx, wait, chat); err != nil {
+		return err
+	}
+	//The global token is reserved when the chat is ready, otherwise the messages which wait for their chats would be sent together after their global tokens have been refilled.
+	global, wait := rl.reserveGlobal(time.Now())
+	return rl.sleep(ctx, wait, chat, global)
+}
+
+/*Waits for the given duration. If the context is done before that, the reserved tokens of the given buckets are given back and the context's error is returned.*/
+func (rl *rateLimiter) sleep(ctx context.Context, wait time.Duration, reserved ...*bucket) error {
+	if wait <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		rl.mu.Lock()
+		for _, b := range reserved {
+			if b != nil {
+				b.cancel()
+			}
+		}
+		rl.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+/*Reserves a token from the bucket of the given chat. Returns the bucket and the time that should be waited.*/
+func (rl *rateLimiter) reserveChat(chatId string, now time.Time) (*bucket, time.Duration) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.cleanup(now)
+	chat := rl.getChatBucket(chatId, now)
+	if chat == nil {
+		return nil, 0
+	}
+	return chat, chat.reserve(now)
+}
+
+/*Reserves a token from the global bucket. Returns the bucket and the time that should be waited.*/
+func (rl *rateLimiter) reserveGlobal(now time.Time) (*bucket, time.Duration) {
+	if rl.global == nil {
+		return nil, 0
+	}
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.global, rl.global.reserve(now)
+}
+
+func (rl *rateLimiter) getChatBucket(chatId string, now time.Time) *bucket {
+	b := rl.chats[chatId]
+	if b == nil {
+		//Private chats have positive ids. Groups, supergroups and channels have negative ids or are addressed by username.
+		if isPrivateChat(chatId) {
+			if rl.cfg.PrivateChatPerSecond <= 0 {
+				return nil
+			}
+			b = newBucket(rl.cfg.PrivateChatPerSecond, time.Second, now)
+		} else {
+			if rl.cfg.GroupPerMinute <= 0 {
+				return nil
+			}
+			b = newBucket(rl.cfg.GroupPerMinute, time.Minute, now)
+		}
+		rl.chats[chatId] = b
+	}
+	return b
+}
+
+/*Removes the buckets that are full, since they are the same as a newly created bucket.*/
+func (rl *rateLimiter) cleanup(now time.Time) {
+	rl.reservesSinceCleanup++
+	if rl.reservesSinceCleanup < 1000 {
+		return
+	}
+	rl.reservesSinceCleanup = 0
+	for chatId, b := range rl.chats {
+		b.refill(now)
+		if b.tokens >= b.capacity {
+			delete(rl.chats, chatId)
+		}
+	}
+}
+
+func isPrivateChat(chatId string) bool {
+	return !strings.HasPrefix(chatId, "-") && !strings.HasPrefix(chatId, "\"")
+}
@@ -0,0 +1,95 @@
+package tba
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func TestRateLimiterPrivateChat(t *testing.T) {
+	rl := newRateLimiter(cfgs.DefaultRateLimitConfigs())
+	now := time.Now()
+	if _, wait := rl.reserveChat("123", now); wait != 0 {
+		t.Error("first message should not wait, waited", wait)
+	}
+	if _, wait := rl.reserveChat("123", now); wait != time.Second {
+		t.Error("second message to the same private chat should wait one second, waited", wait)
+	}
+	if _, wait := rl.reserveChat("456", now); wait != 0 {
+		t.Error("message to another chat should not wait, waited", wait)
+	}
+}
+
+func TestRateLimiterGroup(t *testing.T) {
+	rl := newRateLimiter(cfgs.DefaultRateLimitConfigs())
+	now := time.Now()
+	for i := 0; i < 20; i++ {
+		if _, wait := rl.reserveChat("-100123", now.Add(time.Duration(i)*50*time.Millisecond)); wait != 0 {
+			t.Fatal("message", i, "should not wait, waited", wait)
+		}
+	}
+	if _, wait := rl.reserveChat(`"@channel"`, now); wait != 0 {
+		t.Error("message to another group should not wait, waited", wait)
+	}
+	if _, wait := rl.reserveChat("-100123", now.Add(time.Second)); wait <= 0 {
+		t.Error("21st message in a minute should wait")
+	}
+}
+
+func TestRateLimiterGlobal(t *testing.T) {
+	rl := newRateLimiter(cfgs.DefaultRateLimitConfigs())
+	now := time.Now()
+	var wait time.Duration
+	for i := 0; i < 31; i++ {
+		_, wait = rl.reserveGlobal(now)
+	}
+	if wait <= 0 || wait > time.Second/30+time.Millisecond {
+		t.Error("31st message in a second should wait about 1/30 second, waited", wait)
+	}
+}
+
+func TestRateLimiterGlobalManyChats(t *testing.T) {
+	rl := newRateLimiter(&cfgs.RateLimitConfigs{GlobalPerSecond: 20, PrivateChatPerSecond: 1})
+	var mu sync.Mutex
+	var sent []time.Time
+	var wg sync.WaitGroup
+	send := func(chatId int) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := rl.wait(context.Background(), &objs.SendMessageArgs{DefaultSendMethodsArguments: objs.DefaultSendMethodsArguments{ChatId: []byte(strconv.Itoa(chatId))}}); err != nil {
+				t.Error(err)
+			}
+			mu.Lock()
+			sent = append(sent, time.Now())
+			mu.Unlock()
+		}()
+	}
+	//The second messages of these chats wait one second for their chats.
+	for i := 0; i < 10; i++ {
+		send(i)
+		send(i)
+	}
+	//The global bucket is almost full again when these messages are sent.
+	time.Sleep(950 * time.Millisecond)
+	for i := 10; i < 28; i++ {
+		send(i)
+	}
+	wg.Wait()
+	sort.Slice(sent, func(i, j int) bool { return sent[i].Before(sent[j]) })
+	//A bucket of 20 tokens per second allows at most 20 messages plus 20 per second in any period. One message is tolerated for the timer delays.
+	for i := range sent {
+		for j := i; j < len(sent); j++ {
+			allowed := 20 + int(20*sent[j].Sub(sent[i]).Seconds()) + 1
+			if j-i+1 > allowed {
+				t.Fatalf("%d messages were sent in %v", j-i+1, sent[j].Sub(sent[i]))
+			}
+		}
+	}
+}
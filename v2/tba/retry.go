@@ -0,0 +1,111 @@
+package tba
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	errs "github.com/SakoDroid/telego/v2/errors"
+	logger "github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+// chatIdSetter is implemented by the arguments of the methods which their target chat can be changed.
+type chatIdSetter interface {
+	SetChatId(chatId int)
+}
+
+/*Retries the failed request based on the retry configs of the bot. lastErr is the error of the first attempt.*/
+func (bai *BotAPIInterface) retry(ctx context.Context, lastErr error, methodName string, args objs.MethodArguments, MP bool, files ...*objs.InputFile) ([]byte, error) {
+	rc := bai.botConfigs.RetryConfigs
+	for try := 1; try <= rc.MaxRetries; try++ {
+		wait, ok := bai.getRetryWait(rc, lastErr, args, try)
+		if !ok || !filesReusable(files) {
+			break
+		}
+		bai.logger.Log(methodName, "\t\t\t", "Retry  ", "attempt "+strconv.Itoa(try)+" after "+wait.String(), logger.BOLD+logger.OKBLUE, logger.WARNING, "")
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+		var res []byte
+		res, lastErr = bai.sendCustom(ctx, methodName, args, MP, files...)
+		if lastErr == nil {
+			return res, nil
+		}
+	}
+	return nil, lastErr
+}
+
+/*Decides if the request should be retried or not based on the received error. If so, returns the time that should be waited before retrying.*/
+func (bai *BotAPIInterface) getRetryWait(rc *cfgs.RetryConfigs, err error, args objs.MethodArguments, try int) (time.Duration, bool) {
+	mnse := &errs.MethodNotSentError{}
+	if !errors.As(err, &mnse) {
+		return 0, false
+	}
+	fr := mnse.FailureResult
+	if fr != nil && fr.Parameters != nil {
+		if fr.Parameters.MigrateToChatId != 0 && rc.FollowChatMigration {
+			setter, ok := args.(chatIdSetter)
+			if !ok {
+				return 0, false
+			}
+			bai.logger.GetRaw().Println("Chat has been migrated to", fr.Parameters.MigrateToChatId, ". Resending", mnse.Method, "to the new chat.")
+			setter.SetChatId(fr.Parameters.MigrateToChatId)
+			return 0, true
+		}
+	}
+	if fr != nil && fr.ErrorCode == 429 {
+		if !rc.RetryOnFloodControl {
+			return 0, false
+		}
+		wait := getBackoff(rc, try)
+		if fr.Parameters != nil && fr.Parameters.RetryAfter > 0 {
+			wait = time.Duration(fr.Parameters.RetryAfter) * time.Second
+		}
+		if rc.MaxRetryAfter > 0 && wait > rc.MaxRetryAfter {
+			return 0, false
+		}
+		return wait, true
+	}
+	if !rc.RetryOnServerErrors {
+		return 0, false
+	}
+	if mnse.StatusCode >= 500 || errors.Is(mnse.Err, errs.ErrServerError) || errors.Is(mnse.Err, errs.ErrNetwork) {
+		return getBackoff(rc, try), true
+	}
+	return 0, false
+}
+
+/*Exponential backoff for the given try.*/
+func getBackoff(rc *cfgs.RetryConfigs, try int) time.Duration {
+	backoff, max := rc.Backoff, rc.MaxBackoff
+	if backoff <= 0 {
+		backoff = time.Second
+	}
+	if max <= 0 {
+		max = 30 * time.Second
+	}
+	for i := 1; i < try && backoff < max; i++ {
+		backoff *= 2
+	}
+	if backoff > max {
+		backoff = max
+	}
+	return backoff
+}
+
+/*Returns false if any of the files can not be read again.*/
+func filesReusable(files []*objs.InputFile) bool {
+	for _, file := range files {
+		if file != nil && !file.Reusable() {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,99 @@
+package tba
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	errs "github.com/SakoDroid/telego/v2/errors"
+	logger "github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func createTestInterface(url string, rc *cfgs.RetryConfigs) *BotAPIInterface {
+	cfg := cfgs.Default("token")
+	cfg.BotAPI = url + "/bot"
+	cfg.RetryConfigs = rc
+	return &BotAPIInterface{
+		botConfigs: cfg,
+		logger:     logger.InitTheLogger(cfg),
+		sender:     newHttpSenderClient(cfg.BotAPI, cfg.APIKey, nil),
+	}
+}
+
+func TestRetryFloodControl(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(429)
+			w.Write([]byte(`{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`))
+			return
+		}
+		w.Write([]byte(`{"ok":true,"result":true}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, cfgs.DefaultRetryConfigs())
+	start := time.Now()
+	_, err := bai.SendCustom("sendChatAction", &objs.SendChatActionArgs{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 2 {
+		t.Error("expected 2 calls, got", calls)
+	}
+	if time.Since(start) < time.Second {
+		t.Error("retry_after has not been respected")
+	}
+}
+
+func TestRetryChatMigration(t *testing.T) {
+	var chatIds []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bd := make([]byte, r.ContentLength)
+		r.Body.Read(bd)
+		if strings.Contains(string(bd), `"chat_id":-100123`) {
+			chatIds = append(chatIds, "-100123")
+			w.Write([]byte(`{"ok":true,"result":{"message_id":1}}`))
+			return
+		}
+		chatIds = append(chatIds, "old")
+		w.WriteHeader(400)
+		w.Write([]byte(`{"ok":false,"error_code":400,"description":"Bad Request: group chat was upgraded to a supergroup chat","parameters":{"migrate_to_chat_id":-100123}}`))
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, cfgs.DefaultRetryConfigs())
+	_, err := bai.SendMessage(123, "", "hi", "", nil, nil, false, false, false, 0, 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(chatIds) != 2 || chatIds[1] != "-100123" {
+		t.Error("request was not re-targeted :", chatIds)
+	}
+}
+
+func TestNoRetryWithoutConfigs(t *testing.T) {
+	calls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(502)
+	}))
+	defer srv.Close()
+	bai := createTestInterface(srv.URL, nil)
+	_, err := bai.SendCustom("getMe", nil, false)
+	if err == nil || calls != 1 {
+		t.Error("expected a single failed call, got", calls, err)
+	}
+	if !errors.Is(err, errs.ErrServerError) {
+		t.Error("502 response does not match ErrServerError :", err)
+	}
+	srv.Close()
+	_, err = bai.SendCustom("getMe", nil, false)
+	if !errors.Is(err, errs.ErrNetwork) {
+		t.Error("unreachable server does not match ErrNetwork :", err)
+	}
+}
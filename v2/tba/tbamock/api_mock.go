@@ -0,0 +1,1225 @@
+// Code generated by mockgen. DO NOT EDIT.
+
+package tbamock
+
+import (
+	"context"
+	"encoding/json"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"io"
+	"os"
+)
+
+// funcs contains the functions which are called by the methods of API. If a function is nil, the method returns the default result.
+type funcs struct {
+	StartUpdateRoutineFunc                func() error
+	StartUpdateRoutineCtxFunc             func(ctx context.Context) error
+	StopUpdateRoutineFunc                 func()
+	StopUpdateRoutineCtxFunc              func(ctx context.Context) error
+	ParseUpdateFunc                       func(body []byte) (int, error)
+	GetMeFunc                             func() (*objs.Result[*objs.User], error)
+	LogOutFunc                            func() (*objs.Result[bool], error)
+	CloseFunc                             func() (*objs.Result[bool], error)
+	SendMessageFunc                       func(chatIdInt int, chatIdString string, text string, parseMode string, entities []objs.MessageEntity, linkPreviewOptions *objs.LinkPreviewOptions, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_to_message_id int, messageThreadId int, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	ForwardMessageFunc                    func(chatIdInt int, fromChatIdInt int, chatIdString string, fromChatIdString string, disableNotif bool, ProtectContent bool, messageId int, messageThreadId int) (*objs.Result[*objs.Message], error)
+	SendPhotoFunc                         func(chatIdInt int, chatIdString string, photo string, photoFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, protectContent bool, hasSpoiler bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error)
+	SendVideoFunc                         func(chatIdInt int, chatIdString string, video string, videoFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, protectContent bool, hasSpoiler bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendAudioFunc                         func(chatIdInt int, chatIdString string, audio string, audioFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer string, title string, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendDocumentFunc                      func(chatIdInt int, chatIdString string, document string, documentFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendAnimationFunc                     func(chatIdInt int, chatIdString string, animation string, animationFile *objs.InputFile, caption string, parseMode string, width int, height int, duration int, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, protectContent bool, hasSpoiler bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendVoiceFunc                         func(chatIdInt int, chatIdString string, voice string, voiceFile *objs.InputFile, caption string, parseMode string, duration int, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendVideoNoteFunc                     func(chatIdInt int, chatIdString string, videoNote string, videoNoteFile *objs.InputFile, caption string, parseMode string, length int, duration int, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendMediaGroupFunc                    func(chatIdInt int, chatIdString string, reply_to_message_id int, messageThreadId int, media []objs.InputMedia, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...*objs.InputFile) (*objs.Result[[]objs.Message], error)
+	SendLocationFunc                      func(chatIdInt int, chatIdString string, latitude float32, longitude float32, horizontalAccuracy float32, livePeriod int, heading int, proximityAlertRadius int, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	EditMessageLiveLocationFunc           func(chatIdInt int, chatIdString string, inlineMessageId string, messageId int, latitude float32, longitude float32, horizontalAccuracy float32, heading int, proximityAlertRadius int, reply_markup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	StopMessageLiveLocationFunc           func(chatIdInt int, chatIdString string, inlineMessageId string, messageId int, replyMarkup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	SendVenueFunc                         func(chatIdInt int, chatIdString string, latitude float32, longitude float32, title string, address string, fourSquareId string, fourSquareType string, googlePlaceId string, googlePlaceType string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendContactFunc                       func(chatIdInt int, chatIdString string, phoneNumber string, firstName string, lastName string, vCard string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendPollFunc                          func(chatIdInt int, chatIdString string, question string, options []string, isClosed bool, isAnonymous bool, pollType string, allowMultipleAnswers bool, correctOptionIndex int, explanation string, explanationParseMode string, explanationEntities []objs.MessageEntity, openPeriod int, closeDate int, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendDiceFunc                          func(chatIdInt int, chatIdString string, emoji string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SendChatActionFunc                    func(chatIdInt int, messageThreadId int, chatIdString string, chatAction string) (*objs.Result[*objs.Message], error)
+	GetUserProfilePhotosFunc              func(userId int, offset int, limit int) (*objs.Result[*objs.UserProfilePhotos], error)
+	GetFileFunc                           func(fileId string) (*objs.Result[*objs.File], error)
+	DownloadFileFunc                      func(fileObject *objs.File, file *os.File) error
+	BanChatMemberFunc                     func(chatIdInt int, chatIdString string, userId int, untilDate int, revokeMessages bool) (*objs.Result[bool], error)
+	UnbanChatMemberFunc                   func(chatIdInt int, chatIdString string, userId int, onlyIfBanned bool) (*objs.Result[bool], error)
+	RestrictChatMemberFunc                func(chatIdInt int, chatIdString string, userId int, permissions objs.ChatPermissions, useIndependentChatPermissions bool, untilDate int) (*objs.Result[bool], error)
+	PromoteChatMemberFunc                 func(chatIdInt int, chatIdString string, userId int, isAnonymous bool, canManageChat bool, canPostmessages bool, canEditMessages bool, canDeleteMessages bool, canPostStories bool, canEditStories bool, canDeleteStoreis bool, canManageVideoChats bool, canRestrictMembers bool, canPromoteMembers bool, canChangeInfo bool, canInviteUsers bool, canPinMessages bool, canManageTopics bool) (*objs.Result[bool], error)
+	SetMyDefaultAdministratorRightsFunc   func(forChannels bool, isAnonymous bool, canManageChat bool, canPostmessages bool, canEditMessages bool, canDeleteMessages bool, canManageVideoChats bool, canRestrictMembers bool, canPromoteMembers bool, canChangeInfo bool, canInviteUsers bool, canPinMessages bool) (*objs.Result[bool], error)
+	GetMyDefaultAdministratorRightsFunc   func(forChannels bool) (*objs.Result[*objs.ChatAdministratorRights], error)
+	SetChatAdministratorCustomTitleFunc   func(chatIdInt int, chatIdString string, userId int, customTitle string) (*objs.Result[bool], error)
+	BanOrUnbanChatSenderChatFunc          func(chatIdInt int, chatIdString string, senderChatId int, ban bool) (*objs.Result[bool], error)
+	SetChatPermissionsFunc                func(chatIdInt int, chatIdString string, useIndependentChatPermissions bool, permissions objs.ChatPermissions) (*objs.Result[bool], error)
+	ExportChatInviteLinkFunc              func(chatIdInt int, chatIdString string) (*objs.Result[string], error)
+	CreateChatInviteLinkFunc              func(chatIdInt int, chatIdString string, name string, expireDate int, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error)
+	EditChatInviteLinkFunc                func(chatIdInt int, chatIdString string, inviteLink string, name string, expireDate int, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error)
+	RevokeChatInviteLinkFunc              func(chatIdInt int, chatIdString string, inviteLink string) (*objs.Result[*objs.ChatInviteLink], error)
+	ApproveChatJoinRequestFunc            func(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error)
+	DeclineChatJoinRequestFunc            func(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error)
+	SetChatPhotoFunc                      func(chatIdInt int, chatIdString string, file *objs.InputFile) (*objs.Result[bool], error)
+	DeleteChatPhotoFunc                   func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	SetChatTitleFunc                      func(chatIdInt int, chatIdString string, title string) (*objs.Result[bool], error)
+	SetChatDescriptionFunc                func(chatIdInt int, chatIdString string, descriptions string) (*objs.Result[bool], error)
+	PinChatMessageFunc                    func(chatIdInt int, chatIdString string, messageId int, disableNotification bool) (*objs.Result[bool], error)
+	UnpinChatMessageFunc                  func(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error)
+	UnpinAllChatMessagesFunc              func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	LeaveChatFunc                         func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	GetChatFunc                           func(chatIdInt int, chatIdString string) (*objs.Result[*objs.Chat], error)
+	GetChatAdministratorsFunc             func(chatIdInt int, chatIdString string) (*objs.Result[[]objs.ChatMemberOwner], error)
+	GetChatMemberCountFunc                func(chatIdInt int, chatIdString string) (*objs.Result[int], error)
+	GetChatMemberFunc                     func(chatIdInt int, chatIdString string, userId int) (*objs.Result[json.RawMessage], error)
+	SetChatStickerSetFunc                 func(chatIdInt int, chatIdString string, stickerSetName string) (*objs.Result[bool], error)
+	DeleteChatStickerSetFunc              func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	AnswerCallbackQueryFunc               func(callbackQueryId string, text string, url string, showAlert bool, CacheTime int) (*objs.Result[bool], error)
+	SetMyCommandsFunc                     func(commands []objs.BotCommand, scope objs.BotCommandScope, languageCode string) (*objs.Result[bool], error)
+	DeleteMyCommandsFunc                  func(scope objs.BotCommandScope, languageCode string) (*objs.Result[bool], error)
+	GetMyCommandsFunc                     func(scope objs.BotCommandScope, languageCode string) (*objs.Result[[]objs.BotCommand], error)
+	EditMessageTextFunc                   func(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, text string, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	EditMessageCaptionFunc                func(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, caption string, parseMode string, captionEntities []objs.MessageEntity, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	EditMessageMediaFunc                  func(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...*objs.InputFile) (*objs.Result[json.RawMessage], error)
+	EditMessagereplyMarkupFunc            func(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error)
+	StopPollFunc                          func(chatIdInt int, chatIdString string, messageId int, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[*objs.Poll], error)
+	DeleteMessageFunc                     func(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error)
+	SendStickerFunc                       func(chatIdInt int, chatIdString string, sticker string, emoji string, disableNotif bool, allowSendingWithoutreply bool, protectContent bool, replyTo int, messageThreadId int, replyMarkup objs.ReplyMarkup, file *objs.InputFile) (*objs.Result[*objs.Message], error)
+	GetStickerSetFunc                     func(name string) (*objs.Result[*objs.StickerSet], error)
+	UploadStickerFileFunc                 func(userId int, stickerFormat string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[*objs.File], error)
+	CreateNewStickerSetFunc               func(userId int, name string, title string, StickerFormat string, StickerType string, needsRepainting bool, stickers []*objs.InputSticker, files ...*objs.InputFile) (*objs.Result[bool], error)
+	AddStickerToSetFunc                   func(userId int, name string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[bool], error)
+	SetStickerPositionInSetFunc           func(sticker string, position int) (*objs.Result[bool], error)
+	DeleteStickerFromSetFunc              func(sticker string) (*objs.Result[bool], error)
+	SetStickerSetThumbFunc                func(name string, thumb string, userId int, file *objs.InputFile) (*objs.Result[bool], error)
+	DeleteStickerSetFunc                  func(name string) (*objs.Result[bool], error)
+	SetStickerSetTitleFunc                func(name string, title string) (*objs.Result[bool], error)
+	SetStickerEmojiListFunc               func(sticker string, emojiLst []string) (*objs.Result[bool], error)
+	SetStickerKeywordsFunc                func(sticker string, keywords []string) (*objs.Result[bool], error)
+	SetStickerMaskPositionFunc            func(sticker string, maskPosition *objs.MaskPosition) (*objs.Result[bool], error)
+	AnswerInlineQueryFunc                 func(inlineQueryId string, results []objs.InlineQueryResult, cacheTime int, isPersonal bool, nextOffset string, button *objs.InlineQueryResultsButton) (*objs.Result[bool], error)
+	SendInvoiceFunc                       func(chatIdInt int, chatIdString string, title string, description string, payload string, providerToken string, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter string, providerData string, photoURL string, photoSize int, photoWidth int, photoHeight int, needName bool, needPhoneNumber bool, needEmail bool, needSippingAddress bool, sendPhoneNumberToProvider bool, sendEmailToProvider bool, isFlexible bool, disableNotif bool, replyToMessageId int, messageThreadId int, allowSendingWithoutReply bool, replyMarkup objs.InlineKeyboardMarkup) (*objs.Result[*objs.Message], error)
+	CreateInvoiceLinkFunc                 func(title string, description string, payload string, providerToken string, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, providerData string, photoURL string, photoSize int, photoWidth int, photoHeight int, needName bool, needPhoneNumber bool, needEmail bool, needSippingAddress bool, sendPhoneNumberToProvider bool, sendEmailToProvider bool, isFlexible bool) (*objs.Result[string], error)
+	AnswerShippingQueryFunc               func(shippingQueryId string, ok bool, shippingOptions []objs.ShippingOption, errorMessage string) (*objs.Result[bool], error)
+	AnswerPreCheckoutQueryFunc            func(preCheckoutQueryId string, ok bool, errorMessage string) (*objs.Result[bool], error)
+	CopyMessageFunc                       func(chatIdInt int, fromChatIdInt int, chatIdString string, fromChatIdString string, messageId int, disableNotif bool, caption string, parseMode string, replyTo int, allowSendingWihtoutReply bool, ProtectContent bool, replyMarkUp objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error)
+	SetPassportDataErrorsFunc             func(userId int, errors []objs.PassportElementError) (*objs.Result[bool], error)
+	SendGameFunc                          func(chatId int, gameShortName string, disableNotif bool, replyTo int, allowSendingWithoutReply bool, replyMarkup objs.ReplyMarkup) (*objs.Result[*objs.Message], error)
+	SetGameScoreFunc                      func(userId int, score int, force bool, disableEditMessage bool, chatId int, messageId int, inlineMessageId string) (*objs.Result[json.RawMessage], error)
+	GetGameHighScoresFunc                 func(userId int, chatId int, messageId int, inlineMessageId string) (*objs.Result[[]*objs.GameHighScore], error)
+	GetWebhookInfoFunc                    func() (*objs.Result[*objs.WebhookInfo], error)
+	SetWebhookFunc                        func(url string, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile *objs.InputFile) (*objs.Result[bool], error)
+	DeleteWebhookFunc                     func(dropPendingUpdates bool) (*objs.Result[bool], error)
+	GetCustomEmojiStickersFunc            func(customEmojiIds []string) (*objs.Result[[]*objs.Sticker], error)
+	AnswerWebAppQueryFunc                 func(webAppQueryId string, result objs.InlineQueryResult) (*objs.SentWebAppMessage, error)
+	GetChatMenuButtonFunc                 func(chatId int64) (*objs.Result[*objs.MenuButton], error)
+	SetChatMenuButtonFunc                 func(chatId int64, menuButton *objs.MenuButton) (*objs.Result[bool], error)
+	GetForumTopicIconStickersFunc         func() (*objs.Result[[]*objs.Sticker], error)
+	CreateForumTopicFunc                  func(chatIdInt int, chatIdString string, name string, iconCustomEmojiId string, iconColor int) (*objs.Result[*objs.ForumTopic], error)
+	EditForumTopicFunc                    func(chatIdInt int, chatIdString string, name string, iconCustomEmojiId string, messageThreadId int) (*objs.Result[bool], error)
+	CloseForumTopicFunc                   func(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	ReopenForumTopicFunc                  func(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	DeleteForumTopicFunc                  func(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	UnpinAllForumTopicMessagesFunc        func(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error)
+	EditGeneralForumTopicFunc             func(chatIdInt int, chatIdString string, name string) (*objs.Result[bool], error)
+	CloseGeneralForumTopicFunc            func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	ReopenGeneralForumTopicFunc           func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	HideGeneralForumTopicFunc             func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	UnhideGeneralForumTopicFunc           func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	UnpinAllGeneralForumTopicMessagesFunc func(chatIdInt int, chatIdString string) (*objs.Result[bool], error)
+	SetMyDescriptionFunc                  func(description string, languageCode string) (*objs.Result[bool], error)
+	SetMyShortDescriptionFunc             func(description string, languageCode string) (*objs.Result[bool], error)
+	GetMyDescriptionFunc                  func(languageCode string) (*objs.Result[*objs.BotDescription], error)
+	GetMyShortDescriptionFunc             func(languageCode string) (*objs.Result[*objs.BotShortDescription], error)
+	SetMyNameFunc                         func(name string, languageCode string) (*objs.Result[bool], error)
+	GetMyNameFunc                         func(languageCode string) (*objs.Result[*objs.BotName], error)
+	SendCustomFunc                        func(methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error)
+	SendCustomCtxFunc                     func(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error)
+	SendCustomFilesFunc                   func(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*objs.InputFile) ([]byte, error)
+	DownloadToFunc                        func(fileObject *objs.File, w io.Writer, maxSize int64) (int64, error)
+}
+
+// StartUpdateRoutine records the call and calls StartUpdateRoutineFunc if it is set.
+func (m *API) StartUpdateRoutine() error {
+	m.record("StartUpdateRoutine")
+	if m.StartUpdateRoutineFunc != nil {
+		return m.StartUpdateRoutineFunc()
+	}
+	return nil
+}
+
+// StartUpdateRoutineCtx records the call and calls StartUpdateRoutineCtxFunc if it is set.
+func (m *API) StartUpdateRoutineCtx(ctx context.Context) error {
+	m.record("StartUpdateRoutineCtx", ctx)
+	if m.StartUpdateRoutineCtxFunc != nil {
+		return m.StartUpdateRoutineCtxFunc(ctx)
+	}
+	return nil
+}
+
+// StopUpdateRoutine records the call and calls StopUpdateRoutineFunc if it is set.
+func (m *API) StopUpdateRoutine() {
+	m.record("StopUpdateRoutine")
+	if m.StopUpdateRoutineFunc != nil {
+		m.StopUpdateRoutineFunc()
+		return
+	}
+}
+
+// StopUpdateRoutineCtx records the call and calls StopUpdateRoutineCtxFunc if it is set.
+func (m *API) StopUpdateRoutineCtx(ctx context.Context) error {
+	m.record("StopUpdateRoutineCtx", ctx)
+	if m.StopUpdateRoutineCtxFunc != nil {
+		return m.StopUpdateRoutineCtxFunc(ctx)
+	}
+	return nil
+}
+
+// ParseUpdate records the call and calls ParseUpdateFunc if it is set.
+func (m *API) ParseUpdate(body []byte) (int, error) {
+	m.record("ParseUpdate", body)
+	if m.ParseUpdateFunc != nil {
+		return m.ParseUpdateFunc(body)
+	}
+	return 0, nil
+}
+
+// GetMe records the call and calls GetMeFunc if it is set.
+func (m *API) GetMe() (*objs.Result[*objs.User], error) {
+	m.record("GetMe")
+	if m.GetMeFunc != nil {
+		return m.GetMeFunc()
+	}
+	return &objs.Result[*objs.User]{Ok: true}, nil
+}
+
+// LogOut records the call and calls LogOutFunc if it is set.
+func (m *API) LogOut() (*objs.Result[bool], error) {
+	m.record("LogOut")
+	if m.LogOutFunc != nil {
+		return m.LogOutFunc()
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// Close records the call and calls CloseFunc if it is set.
+func (m *API) Close() (*objs.Result[bool], error) {
+	m.record("Close")
+	if m.CloseFunc != nil {
+		return m.CloseFunc()
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SendMessage records the call and calls SendMessageFunc if it is set.
+func (m *API) SendMessage(chatIdInt int, chatIdString string, text string, parseMode string, entities []objs.MessageEntity, linkPreviewOptions *objs.LinkPreviewOptions, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_to_message_id int, messageThreadId int, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendMessage", chatIdInt, chatIdString, text, parseMode, entities, linkPreviewOptions, disable_notification, allow_sending_without_reply, ProtectContent, reply_to_message_id, messageThreadId, reply_markup)
+	if m.SendMessageFunc != nil {
+		return m.SendMessageFunc(chatIdInt, chatIdString, text, parseMode, entities, linkPreviewOptions, disable_notification, allow_sending_without_reply, ProtectContent, reply_to_message_id, messageThreadId, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// ForwardMessage records the call and calls ForwardMessageFunc if it is set.
+func (m *API) ForwardMessage(chatIdInt int, fromChatIdInt int, chatIdString string, fromChatIdString string, disableNotif bool, ProtectContent bool, messageId int, messageThreadId int) (*objs.Result[*objs.Message], error) {
+	m.record("ForwardMessage", chatIdInt, fromChatIdInt, chatIdString, fromChatIdString, disableNotif, ProtectContent, messageId, messageThreadId)
+	if m.ForwardMessageFunc != nil {
+		return m.ForwardMessageFunc(chatIdInt, fromChatIdInt, chatIdString, fromChatIdString, disableNotif, ProtectContent, messageId, messageThreadId)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendPhoto records the call and calls SendPhotoFunc if it is set.
+func (m *API) SendPhoto(chatIdInt int, chatIdString string, photo string, photoFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, protectContent bool, hasSpoiler bool, reply_markup objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
+	m.record("SendPhoto", chatIdInt, chatIdString, photo, photoFile, caption, parseMode, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler, reply_markup, captionEntities)
+	if m.SendPhotoFunc != nil {
+		return m.SendPhotoFunc(chatIdInt, chatIdString, photo, photoFile, caption, parseMode, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler, reply_markup, captionEntities)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendVideo records the call and calls SendVideoFunc if it is set.
+func (m *API) SendVideo(chatIdInt int, chatIdString string, video string, videoFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, protectContent bool, hasSpoiler bool, captionEntities []objs.MessageEntity, duration int, supportsStreaming bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendVideo", chatIdInt, chatIdString, video, videoFile, caption, parseMode, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler, captionEntities, duration, supportsStreaming, reply_markup)
+	if m.SendVideoFunc != nil {
+		return m.SendVideoFunc(chatIdInt, chatIdString, video, videoFile, caption, parseMode, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler, captionEntities, duration, supportsStreaming, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendAudio records the call and calls SendAudioFunc if it is set.
+func (m *API) SendAudio(chatIdInt int, chatIdString string, audio string, audioFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, duration int, performer string, title string, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendAudio", chatIdInt, chatIdString, audio, audioFile, caption, parseMode, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, duration, performer, title, reply_markup)
+	if m.SendAudioFunc != nil {
+		return m.SendAudioFunc(chatIdInt, chatIdString, audio, audioFile, caption, parseMode, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, duration, performer, title, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendDocument records the call and calls SendDocumentFunc if it is set.
+func (m *API) SendDocument(chatIdInt int, chatIdString string, document string, documentFile *objs.InputFile, caption string, parseMode string, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, DisableContentTypeDetection bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendDocument", chatIdInt, chatIdString, document, documentFile, caption, parseMode, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, DisableContentTypeDetection, reply_markup)
+	if m.SendDocumentFunc != nil {
+		return m.SendDocumentFunc(chatIdInt, chatIdString, document, documentFile, caption, parseMode, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, DisableContentTypeDetection, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendAnimation records the call and calls SendAnimationFunc if it is set.
+func (m *API) SendAnimation(chatIdInt int, chatIdString string, animation string, animationFile *objs.InputFile, caption string, parseMode string, width int, height int, duration int, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, protectContent bool, hasSpoiler bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendAnimation", chatIdInt, chatIdString, animation, animationFile, caption, parseMode, width, height, duration, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler, captionEntities, reply_markup)
+	if m.SendAnimationFunc != nil {
+		return m.SendAnimationFunc(chatIdInt, chatIdString, animation, animationFile, caption, parseMode, width, height, duration, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, protectContent, hasSpoiler, captionEntities, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendVoice records the call and calls SendVoiceFunc if it is set.
+func (m *API) SendVoice(chatIdInt int, chatIdString string, voice string, voiceFile *objs.InputFile, caption string, parseMode string, duration int, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendVoice", chatIdInt, chatIdString, voice, voiceFile, caption, parseMode, duration, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, reply_markup)
+	if m.SendVoiceFunc != nil {
+		return m.SendVoiceFunc(chatIdInt, chatIdString, voice, voiceFile, caption, parseMode, duration, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendVideoNote records the call and calls SendVideoNoteFunc if it is set.
+func (m *API) SendVideoNote(chatIdInt int, chatIdString string, videoNote string, videoNoteFile *objs.InputFile, caption string, parseMode string, length int, duration int, reply_to_message_id int, messageThreadId int, thumb string, thumbFile *objs.InputFile, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, captionEntities []objs.MessageEntity, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendVideoNote", chatIdInt, chatIdString, videoNote, videoNoteFile, caption, parseMode, length, duration, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, reply_markup)
+	if m.SendVideoNoteFunc != nil {
+		return m.SendVideoNoteFunc(chatIdInt, chatIdString, videoNote, videoNoteFile, caption, parseMode, length, duration, reply_to_message_id, messageThreadId, thumb, thumbFile, disable_notification, allow_sending_without_reply, ProtectContent, captionEntities, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendMediaGroup records the call and calls SendMediaGroupFunc if it is set.
+func (m *API) SendMediaGroup(chatIdInt int, chatIdString string, reply_to_message_id int, messageThreadId int, media []objs.InputMedia, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup, files ...*objs.InputFile) (*objs.Result[[]objs.Message], error) {
+	m.record("SendMediaGroup", chatIdInt, chatIdString, reply_to_message_id, messageThreadId, media, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup, files)
+	if m.SendMediaGroupFunc != nil {
+		return m.SendMediaGroupFunc(chatIdInt, chatIdString, reply_to_message_id, messageThreadId, media, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup, files...)
+	}
+	return &objs.Result[[]objs.Message]{Ok: true}, nil
+}
+
+// SendLocation records the call and calls SendLocationFunc if it is set.
+func (m *API) SendLocation(chatIdInt int, chatIdString string, latitude float32, longitude float32, horizontalAccuracy float32, livePeriod int, heading int, proximityAlertRadius int, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendLocation", chatIdInt, chatIdString, latitude, longitude, horizontalAccuracy, livePeriod, heading, proximityAlertRadius, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	if m.SendLocationFunc != nil {
+		return m.SendLocationFunc(chatIdInt, chatIdString, latitude, longitude, horizontalAccuracy, livePeriod, heading, proximityAlertRadius, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// EditMessageLiveLocation records the call and calls EditMessageLiveLocationFunc if it is set.
+func (m *API) EditMessageLiveLocation(chatIdInt int, chatIdString string, inlineMessageId string, messageId int, latitude float32, longitude float32, horizontalAccuracy float32, heading int, proximityAlertRadius int, reply_markup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+	m.record("EditMessageLiveLocation", chatIdInt, chatIdString, inlineMessageId, messageId, latitude, longitude, horizontalAccuracy, heading, proximityAlertRadius, reply_markup)
+	if m.EditMessageLiveLocationFunc != nil {
+		return m.EditMessageLiveLocationFunc(chatIdInt, chatIdString, inlineMessageId, messageId, latitude, longitude, horizontalAccuracy, heading, proximityAlertRadius, reply_markup)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// StopMessageLiveLocation records the call and calls StopMessageLiveLocationFunc if it is set.
+func (m *API) StopMessageLiveLocation(chatIdInt int, chatIdString string, inlineMessageId string, messageId int, replyMarkup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+	m.record("StopMessageLiveLocation", chatIdInt, chatIdString, inlineMessageId, messageId, replyMarkup)
+	if m.StopMessageLiveLocationFunc != nil {
+		return m.StopMessageLiveLocationFunc(chatIdInt, chatIdString, inlineMessageId, messageId, replyMarkup)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// SendVenue records the call and calls SendVenueFunc if it is set.
+func (m *API) SendVenue(chatIdInt int, chatIdString string, latitude float32, longitude float32, title string, address string, fourSquareId string, fourSquareType string, googlePlaceId string, googlePlaceType string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendVenue", chatIdInt, chatIdString, latitude, longitude, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	if m.SendVenueFunc != nil {
+		return m.SendVenueFunc(chatIdInt, chatIdString, latitude, longitude, title, address, fourSquareId, fourSquareType, googlePlaceId, googlePlaceType, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendContact records the call and calls SendContactFunc if it is set.
+func (m *API) SendContact(chatIdInt int, chatIdString string, phoneNumber string, firstName string, lastName string, vCard string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendContact", chatIdInt, chatIdString, phoneNumber, firstName, lastName, vCard, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	if m.SendContactFunc != nil {
+		return m.SendContactFunc(chatIdInt, chatIdString, phoneNumber, firstName, lastName, vCard, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendPoll records the call and calls SendPollFunc if it is set.
+func (m *API) SendPoll(chatIdInt int, chatIdString string, question string, options []string, isClosed bool, isAnonymous bool, pollType string, allowMultipleAnswers bool, correctOptionIndex int, explanation string, explanationParseMode string, explanationEntities []objs.MessageEntity, openPeriod int, closeDate int, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendPoll", chatIdInt, chatIdString, question, options, isClosed, isAnonymous, pollType, allowMultipleAnswers, correctOptionIndex, explanation, explanationParseMode, explanationEntities, openPeriod, closeDate, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	if m.SendPollFunc != nil {
+		return m.SendPollFunc(chatIdInt, chatIdString, question, options, isClosed, isAnonymous, pollType, allowMultipleAnswers, correctOptionIndex, explanation, explanationParseMode, explanationEntities, openPeriod, closeDate, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendDice records the call and calls SendDiceFunc if it is set.
+func (m *API) SendDice(chatIdInt int, chatIdString string, emoji string, reply_to_message_id int, messageThreadId int, disable_notification bool, allow_sending_without_reply bool, ProtectContent bool, reply_markup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendDice", chatIdInt, chatIdString, emoji, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	if m.SendDiceFunc != nil {
+		return m.SendDiceFunc(chatIdInt, chatIdString, emoji, reply_to_message_id, messageThreadId, disable_notification, allow_sending_without_reply, ProtectContent, reply_markup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SendChatAction records the call and calls SendChatActionFunc if it is set.
+func (m *API) SendChatAction(chatIdInt int, messageThreadId int, chatIdString string, chatAction string) (*objs.Result[*objs.Message], error) {
+	m.record("SendChatAction", chatIdInt, messageThreadId, chatIdString, chatAction)
+	if m.SendChatActionFunc != nil {
+		return m.SendChatActionFunc(chatIdInt, messageThreadId, chatIdString, chatAction)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// GetUserProfilePhotos records the call and calls GetUserProfilePhotosFunc if it is set.
+func (m *API) GetUserProfilePhotos(userId int, offset int, limit int) (*objs.Result[*objs.UserProfilePhotos], error) {
+	m.record("GetUserProfilePhotos", userId, offset, limit)
+	if m.GetUserProfilePhotosFunc != nil {
+		return m.GetUserProfilePhotosFunc(userId, offset, limit)
+	}
+	return &objs.Result[*objs.UserProfilePhotos]{Ok: true}, nil
+}
+
+// GetFile records the call and calls GetFileFunc if it is set.
+func (m *API) GetFile(fileId string) (*objs.Result[*objs.File], error) {
+	m.record("GetFile", fileId)
+	if m.GetFileFunc != nil {
+		return m.GetFileFunc(fileId)
+	}
+	return &objs.Result[*objs.File]{Ok: true}, nil
+}
+
+// DownloadFile records the call and calls DownloadFileFunc if it is set.
+func (m *API) DownloadFile(fileObject *objs.File, file *os.File) error {
+	m.record("DownloadFile", fileObject, file)
+	if m.DownloadFileFunc != nil {
+		return m.DownloadFileFunc(fileObject, file)
+	}
+	return nil
+}
+
+// BanChatMember records the call and calls BanChatMemberFunc if it is set.
+func (m *API) BanChatMember(chatIdInt int, chatIdString string, userId int, untilDate int, revokeMessages bool) (*objs.Result[bool], error) {
+	m.record("BanChatMember", chatIdInt, chatIdString, userId, untilDate, revokeMessages)
+	if m.BanChatMemberFunc != nil {
+		return m.BanChatMemberFunc(chatIdInt, chatIdString, userId, untilDate, revokeMessages)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// UnbanChatMember records the call and calls UnbanChatMemberFunc if it is set.
+func (m *API) UnbanChatMember(chatIdInt int, chatIdString string, userId int, onlyIfBanned bool) (*objs.Result[bool], error) {
+	m.record("UnbanChatMember", chatIdInt, chatIdString, userId, onlyIfBanned)
+	if m.UnbanChatMemberFunc != nil {
+		return m.UnbanChatMemberFunc(chatIdInt, chatIdString, userId, onlyIfBanned)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// RestrictChatMember records the call and calls RestrictChatMemberFunc if it is set.
+func (m *API) RestrictChatMember(chatIdInt int, chatIdString string, userId int, permissions objs.ChatPermissions, useIndependentChatPermissions bool, untilDate int) (*objs.Result[bool], error) {
+	m.record("RestrictChatMember", chatIdInt, chatIdString, userId, permissions, useIndependentChatPermissions, untilDate)
+	if m.RestrictChatMemberFunc != nil {
+		return m.RestrictChatMemberFunc(chatIdInt, chatIdString, userId, permissions, useIndependentChatPermissions, untilDate)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// PromoteChatMember records the call and calls PromoteChatMemberFunc if it is set.
+func (m *API) PromoteChatMember(chatIdInt int, chatIdString string, userId int, isAnonymous bool, canManageChat bool, canPostmessages bool, canEditMessages bool, canDeleteMessages bool, canPostStories bool, canEditStories bool, canDeleteStoreis bool, canManageVideoChats bool, canRestrictMembers bool, canPromoteMembers bool, canChangeInfo bool, canInviteUsers bool, canPinMessages bool, canManageTopics bool) (*objs.Result[bool], error) {
+	m.record("PromoteChatMember", chatIdInt, chatIdString, userId, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics)
+	if m.PromoteChatMemberFunc != nil {
+		return m.PromoteChatMemberFunc(chatIdInt, chatIdString, userId, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canPostStories, canEditStories, canDeleteStoreis, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages, canManageTopics)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetMyDefaultAdministratorRights records the call and calls SetMyDefaultAdministratorRightsFunc if it is set.
+func (m *API) SetMyDefaultAdministratorRights(forChannels bool, isAnonymous bool, canManageChat bool, canPostmessages bool, canEditMessages bool, canDeleteMessages bool, canManageVideoChats bool, canRestrictMembers bool, canPromoteMembers bool, canChangeInfo bool, canInviteUsers bool, canPinMessages bool) (*objs.Result[bool], error) {
+	m.record("SetMyDefaultAdministratorRights", forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
+	if m.SetMyDefaultAdministratorRightsFunc != nil {
+		return m.SetMyDefaultAdministratorRightsFunc(forChannels, isAnonymous, canManageChat, canPostmessages, canEditMessages, canDeleteMessages, canManageVideoChats, canRestrictMembers, canPromoteMembers, canChangeInfo, canInviteUsers, canPinMessages)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// GetMyDefaultAdministratorRights records the call and calls GetMyDefaultAdministratorRightsFunc if it is set.
+func (m *API) GetMyDefaultAdministratorRights(forChannels bool) (*objs.Result[*objs.ChatAdministratorRights], error) {
+	m.record("GetMyDefaultAdministratorRights", forChannels)
+	if m.GetMyDefaultAdministratorRightsFunc != nil {
+		return m.GetMyDefaultAdministratorRightsFunc(forChannels)
+	}
+	return &objs.Result[*objs.ChatAdministratorRights]{Ok: true}, nil
+}
+
+// SetChatAdministratorCustomTitle records the call and calls SetChatAdministratorCustomTitleFunc if it is set.
+func (m *API) SetChatAdministratorCustomTitle(chatIdInt int, chatIdString string, userId int, customTitle string) (*objs.Result[bool], error) {
+	m.record("SetChatAdministratorCustomTitle", chatIdInt, chatIdString, userId, customTitle)
+	if m.SetChatAdministratorCustomTitleFunc != nil {
+		return m.SetChatAdministratorCustomTitleFunc(chatIdInt, chatIdString, userId, customTitle)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// BanOrUnbanChatSenderChat records the call and calls BanOrUnbanChatSenderChatFunc if it is set.
+func (m *API) BanOrUnbanChatSenderChat(chatIdInt int, chatIdString string, senderChatId int, ban bool) (*objs.Result[bool], error) {
+	m.record("BanOrUnbanChatSenderChat", chatIdInt, chatIdString, senderChatId, ban)
+	if m.BanOrUnbanChatSenderChatFunc != nil {
+		return m.BanOrUnbanChatSenderChatFunc(chatIdInt, chatIdString, senderChatId, ban)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetChatPermissions records the call and calls SetChatPermissionsFunc if it is set.
+func (m *API) SetChatPermissions(chatIdInt int, chatIdString string, useIndependentChatPermissions bool, permissions objs.ChatPermissions) (*objs.Result[bool], error) {
+	m.record("SetChatPermissions", chatIdInt, chatIdString, useIndependentChatPermissions, permissions)
+	if m.SetChatPermissionsFunc != nil {
+		return m.SetChatPermissionsFunc(chatIdInt, chatIdString, useIndependentChatPermissions, permissions)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// ExportChatInviteLink records the call and calls ExportChatInviteLinkFunc if it is set.
+func (m *API) ExportChatInviteLink(chatIdInt int, chatIdString string) (*objs.Result[string], error) {
+	m.record("ExportChatInviteLink", chatIdInt, chatIdString)
+	if m.ExportChatInviteLinkFunc != nil {
+		return m.ExportChatInviteLinkFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[string]{Ok: true}, nil
+}
+
+// CreateChatInviteLink records the call and calls CreateChatInviteLinkFunc if it is set.
+func (m *API) CreateChatInviteLink(chatIdInt int, chatIdString string, name string, expireDate int, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
+	m.record("CreateChatInviteLink", chatIdInt, chatIdString, name, expireDate, memberLimit, createsJoinRequest)
+	if m.CreateChatInviteLinkFunc != nil {
+		return m.CreateChatInviteLinkFunc(chatIdInt, chatIdString, name, expireDate, memberLimit, createsJoinRequest)
+	}
+	return &objs.Result[*objs.ChatInviteLink]{Ok: true}, nil
+}
+
+// EditChatInviteLink records the call and calls EditChatInviteLinkFunc if it is set.
+func (m *API) EditChatInviteLink(chatIdInt int, chatIdString string, inviteLink string, name string, expireDate int, memberLimit int, createsJoinRequest bool) (*objs.Result[*objs.ChatInviteLink], error) {
+	m.record("EditChatInviteLink", chatIdInt, chatIdString, inviteLink, name, expireDate, memberLimit, createsJoinRequest)
+	if m.EditChatInviteLinkFunc != nil {
+		return m.EditChatInviteLinkFunc(chatIdInt, chatIdString, inviteLink, name, expireDate, memberLimit, createsJoinRequest)
+	}
+	return &objs.Result[*objs.ChatInviteLink]{Ok: true}, nil
+}
+
+// RevokeChatInviteLink records the call and calls RevokeChatInviteLinkFunc if it is set.
+func (m *API) RevokeChatInviteLink(chatIdInt int, chatIdString string, inviteLink string) (*objs.Result[*objs.ChatInviteLink], error) {
+	m.record("RevokeChatInviteLink", chatIdInt, chatIdString, inviteLink)
+	if m.RevokeChatInviteLinkFunc != nil {
+		return m.RevokeChatInviteLinkFunc(chatIdInt, chatIdString, inviteLink)
+	}
+	return &objs.Result[*objs.ChatInviteLink]{Ok: true}, nil
+}
+
+// ApproveChatJoinRequest records the call and calls ApproveChatJoinRequestFunc if it is set.
+func (m *API) ApproveChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error) {
+	m.record("ApproveChatJoinRequest", chatIdInt, chatIdString, userId)
+	if m.ApproveChatJoinRequestFunc != nil {
+		return m.ApproveChatJoinRequestFunc(chatIdInt, chatIdString, userId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeclineChatJoinRequest records the call and calls DeclineChatJoinRequestFunc if it is set.
+func (m *API) DeclineChatJoinRequest(chatIdInt int, chatIdString string, userId int) (*objs.Result[bool], error) {
+	m.record("DeclineChatJoinRequest", chatIdInt, chatIdString, userId)
+	if m.DeclineChatJoinRequestFunc != nil {
+		return m.DeclineChatJoinRequestFunc(chatIdInt, chatIdString, userId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetChatPhoto records the call and calls SetChatPhotoFunc if it is set.
+func (m *API) SetChatPhoto(chatIdInt int, chatIdString string, file *objs.InputFile) (*objs.Result[bool], error) {
+	m.record("SetChatPhoto", chatIdInt, chatIdString, file)
+	if m.SetChatPhotoFunc != nil {
+		return m.SetChatPhotoFunc(chatIdInt, chatIdString, file)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeleteChatPhoto records the call and calls DeleteChatPhotoFunc if it is set.
+func (m *API) DeleteChatPhoto(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("DeleteChatPhoto", chatIdInt, chatIdString)
+	if m.DeleteChatPhotoFunc != nil {
+		return m.DeleteChatPhotoFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetChatTitle records the call and calls SetChatTitleFunc if it is set.
+func (m *API) SetChatTitle(chatIdInt int, chatIdString string, title string) (*objs.Result[bool], error) {
+	m.record("SetChatTitle", chatIdInt, chatIdString, title)
+	if m.SetChatTitleFunc != nil {
+		return m.SetChatTitleFunc(chatIdInt, chatIdString, title)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetChatDescription records the call and calls SetChatDescriptionFunc if it is set.
+func (m *API) SetChatDescription(chatIdInt int, chatIdString string, descriptions string) (*objs.Result[bool], error) {
+	m.record("SetChatDescription", chatIdInt, chatIdString, descriptions)
+	if m.SetChatDescriptionFunc != nil {
+		return m.SetChatDescriptionFunc(chatIdInt, chatIdString, descriptions)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// PinChatMessage records the call and calls PinChatMessageFunc if it is set.
+func (m *API) PinChatMessage(chatIdInt int, chatIdString string, messageId int, disableNotification bool) (*objs.Result[bool], error) {
+	m.record("PinChatMessage", chatIdInt, chatIdString, messageId, disableNotification)
+	if m.PinChatMessageFunc != nil {
+		return m.PinChatMessageFunc(chatIdInt, chatIdString, messageId, disableNotification)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// UnpinChatMessage records the call and calls UnpinChatMessageFunc if it is set.
+func (m *API) UnpinChatMessage(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error) {
+	m.record("UnpinChatMessage", chatIdInt, chatIdString, messageId)
+	if m.UnpinChatMessageFunc != nil {
+		return m.UnpinChatMessageFunc(chatIdInt, chatIdString, messageId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// UnpinAllChatMessages records the call and calls UnpinAllChatMessagesFunc if it is set.
+func (m *API) UnpinAllChatMessages(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("UnpinAllChatMessages", chatIdInt, chatIdString)
+	if m.UnpinAllChatMessagesFunc != nil {
+		return m.UnpinAllChatMessagesFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// LeaveChat records the call and calls LeaveChatFunc if it is set.
+func (m *API) LeaveChat(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("LeaveChat", chatIdInt, chatIdString)
+	if m.LeaveChatFunc != nil {
+		return m.LeaveChatFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// GetChat records the call and calls GetChatFunc if it is set.
+func (m *API) GetChat(chatIdInt int, chatIdString string) (*objs.Result[*objs.Chat], error) {
+	m.record("GetChat", chatIdInt, chatIdString)
+	if m.GetChatFunc != nil {
+		return m.GetChatFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[*objs.Chat]{Ok: true}, nil
+}
+
+// GetChatAdministrators records the call and calls GetChatAdministratorsFunc if it is set.
+func (m *API) GetChatAdministrators(chatIdInt int, chatIdString string) (*objs.Result[[]objs.ChatMemberOwner], error) {
+	m.record("GetChatAdministrators", chatIdInt, chatIdString)
+	if m.GetChatAdministratorsFunc != nil {
+		return m.GetChatAdministratorsFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[[]objs.ChatMemberOwner]{Ok: true}, nil
+}
+
+// GetChatMemberCount records the call and calls GetChatMemberCountFunc if it is set.
+func (m *API) GetChatMemberCount(chatIdInt int, chatIdString string) (*objs.Result[int], error) {
+	m.record("GetChatMemberCount", chatIdInt, chatIdString)
+	if m.GetChatMemberCountFunc != nil {
+		return m.GetChatMemberCountFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[int]{Ok: true}, nil
+}
+
+// GetChatMember records the call and calls GetChatMemberFunc if it is set.
+func (m *API) GetChatMember(chatIdInt int, chatIdString string, userId int) (*objs.Result[json.RawMessage], error) {
+	m.record("GetChatMember", chatIdInt, chatIdString, userId)
+	if m.GetChatMemberFunc != nil {
+		return m.GetChatMemberFunc(chatIdInt, chatIdString, userId)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// SetChatStickerSet records the call and calls SetChatStickerSetFunc if it is set.
+func (m *API) SetChatStickerSet(chatIdInt int, chatIdString string, stickerSetName string) (*objs.Result[bool], error) {
+	m.record("SetChatStickerSet", chatIdInt, chatIdString, stickerSetName)
+	if m.SetChatStickerSetFunc != nil {
+		return m.SetChatStickerSetFunc(chatIdInt, chatIdString, stickerSetName)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeleteChatStickerSet records the call and calls DeleteChatStickerSetFunc if it is set.
+func (m *API) DeleteChatStickerSet(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("DeleteChatStickerSet", chatIdInt, chatIdString)
+	if m.DeleteChatStickerSetFunc != nil {
+		return m.DeleteChatStickerSetFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// AnswerCallbackQuery records the call and calls AnswerCallbackQueryFunc if it is set.
+func (m *API) AnswerCallbackQuery(callbackQueryId string, text string, url string, showAlert bool, CacheTime int) (*objs.Result[bool], error) {
+	m.record("AnswerCallbackQuery", callbackQueryId, text, url, showAlert, CacheTime)
+	if m.AnswerCallbackQueryFunc != nil {
+		return m.AnswerCallbackQueryFunc(callbackQueryId, text, url, showAlert, CacheTime)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetMyCommands records the call and calls SetMyCommandsFunc if it is set.
+func (m *API) SetMyCommands(commands []objs.BotCommand, scope objs.BotCommandScope, languageCode string) (*objs.Result[bool], error) {
+	m.record("SetMyCommands", commands, scope, languageCode)
+	if m.SetMyCommandsFunc != nil {
+		return m.SetMyCommandsFunc(commands, scope, languageCode)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeleteMyCommands records the call and calls DeleteMyCommandsFunc if it is set.
+func (m *API) DeleteMyCommands(scope objs.BotCommandScope, languageCode string) (*objs.Result[bool], error) {
+	m.record("DeleteMyCommands", scope, languageCode)
+	if m.DeleteMyCommandsFunc != nil {
+		return m.DeleteMyCommandsFunc(scope, languageCode)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// GetMyCommands records the call and calls GetMyCommandsFunc if it is set.
+func (m *API) GetMyCommands(scope objs.BotCommandScope, languageCode string) (*objs.Result[[]objs.BotCommand], error) {
+	m.record("GetMyCommands", scope, languageCode)
+	if m.GetMyCommandsFunc != nil {
+		return m.GetMyCommandsFunc(scope, languageCode)
+	}
+	return &objs.Result[[]objs.BotCommand]{Ok: true}, nil
+}
+
+// EditMessageText records the call and calls EditMessageTextFunc if it is set.
+func (m *API) EditMessageText(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, text string, parseMode string, entities []objs.MessageEntity, disableWebPagePreview bool, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+	m.record("EditMessageText", chatIdInt, chatIdString, messageId, inlineMessageId, text, parseMode, entities, disableWebPagePreview, replyMakrup)
+	if m.EditMessageTextFunc != nil {
+		return m.EditMessageTextFunc(chatIdInt, chatIdString, messageId, inlineMessageId, text, parseMode, entities, disableWebPagePreview, replyMakrup)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// EditMessageCaption records the call and calls EditMessageCaptionFunc if it is set.
+func (m *API) EditMessageCaption(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, caption string, parseMode string, captionEntities []objs.MessageEntity, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+	m.record("EditMessageCaption", chatIdInt, chatIdString, messageId, inlineMessageId, caption, parseMode, captionEntities, replyMakrup)
+	if m.EditMessageCaptionFunc != nil {
+		return m.EditMessageCaptionFunc(chatIdInt, chatIdString, messageId, inlineMessageId, caption, parseMode, captionEntities, replyMakrup)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// EditMessageMedia records the call and calls EditMessageMediaFunc if it is set.
+func (m *API) EditMessageMedia(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, media objs.InputMedia, replyMakrup *objs.InlineKeyboardMarkup, file ...*objs.InputFile) (*objs.Result[json.RawMessage], error) {
+	m.record("EditMessageMedia", chatIdInt, chatIdString, messageId, inlineMessageId, media, replyMakrup, file)
+	if m.EditMessageMediaFunc != nil {
+		return m.EditMessageMediaFunc(chatIdInt, chatIdString, messageId, inlineMessageId, media, replyMakrup, file...)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// EditMessagereplyMarkup records the call and calls EditMessagereplyMarkupFunc if it is set.
+func (m *API) EditMessagereplyMarkup(chatIdInt int, chatIdString string, messageId int, inlineMessageId string, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[json.RawMessage], error) {
+	m.record("EditMessagereplyMarkup", chatIdInt, chatIdString, messageId, inlineMessageId, replyMakrup)
+	if m.EditMessagereplyMarkupFunc != nil {
+		return m.EditMessagereplyMarkupFunc(chatIdInt, chatIdString, messageId, inlineMessageId, replyMakrup)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// StopPoll records the call and calls StopPollFunc if it is set.
+func (m *API) StopPoll(chatIdInt int, chatIdString string, messageId int, replyMakrup *objs.InlineKeyboardMarkup) (*objs.Result[*objs.Poll], error) {
+	m.record("StopPoll", chatIdInt, chatIdString, messageId, replyMakrup)
+	if m.StopPollFunc != nil {
+		return m.StopPollFunc(chatIdInt, chatIdString, messageId, replyMakrup)
+	}
+	return &objs.Result[*objs.Poll]{Ok: true}, nil
+}
+
+// DeleteMessage records the call and calls DeleteMessageFunc if it is set.
+func (m *API) DeleteMessage(chatIdInt int, chatIdString string, messageId int) (*objs.Result[bool], error) {
+	m.record("DeleteMessage", chatIdInt, chatIdString, messageId)
+	if m.DeleteMessageFunc != nil {
+		return m.DeleteMessageFunc(chatIdInt, chatIdString, messageId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SendSticker records the call and calls SendStickerFunc if it is set.
+func (m *API) SendSticker(chatIdInt int, chatIdString string, sticker string, emoji string, disableNotif bool, allowSendingWithoutreply bool, protectContent bool, replyTo int, messageThreadId int, replyMarkup objs.ReplyMarkup, file *objs.InputFile) (*objs.Result[*objs.Message], error) {
+	m.record("SendSticker", chatIdInt, chatIdString, sticker, emoji, disableNotif, allowSendingWithoutreply, protectContent, replyTo, messageThreadId, replyMarkup, file)
+	if m.SendStickerFunc != nil {
+		return m.SendStickerFunc(chatIdInt, chatIdString, sticker, emoji, disableNotif, allowSendingWithoutreply, protectContent, replyTo, messageThreadId, replyMarkup, file)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// GetStickerSet records the call and calls GetStickerSetFunc if it is set.
+func (m *API) GetStickerSet(name string) (*objs.Result[*objs.StickerSet], error) {
+	m.record("GetStickerSet", name)
+	if m.GetStickerSetFunc != nil {
+		return m.GetStickerSetFunc(name)
+	}
+	return &objs.Result[*objs.StickerSet]{Ok: true}, nil
+}
+
+// UploadStickerFile records the call and calls UploadStickerFileFunc if it is set.
+func (m *API) UploadStickerFile(userId int, stickerFormat string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[*objs.File], error) {
+	m.record("UploadStickerFile", userId, stickerFormat, sticker, file)
+	if m.UploadStickerFileFunc != nil {
+		return m.UploadStickerFileFunc(userId, stickerFormat, sticker, file)
+	}
+	return &objs.Result[*objs.File]{Ok: true}, nil
+}
+
+// CreateNewStickerSet records the call and calls CreateNewStickerSetFunc if it is set.
+func (m *API) CreateNewStickerSet(userId int, name string, title string, StickerFormat string, StickerType string, needsRepainting bool, stickers []*objs.InputSticker, files ...*objs.InputFile) (*objs.Result[bool], error) {
+	m.record("CreateNewStickerSet", userId, name, title, StickerFormat, StickerType, needsRepainting, stickers, files)
+	if m.CreateNewStickerSetFunc != nil {
+		return m.CreateNewStickerSetFunc(userId, name, title, StickerFormat, StickerType, needsRepainting, stickers, files...)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// AddStickerToSet records the call and calls AddStickerToSetFunc if it is set.
+func (m *API) AddStickerToSet(userId int, name string, sticker *objs.InputSticker, file *objs.InputFile) (*objs.Result[bool], error) {
+	m.record("AddStickerToSet", userId, name, sticker, file)
+	if m.AddStickerToSetFunc != nil {
+		return m.AddStickerToSetFunc(userId, name, sticker, file)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetStickerPositionInSet records the call and calls SetStickerPositionInSetFunc if it is set.
+func (m *API) SetStickerPositionInSet(sticker string, position int) (*objs.Result[bool], error) {
+	m.record("SetStickerPositionInSet", sticker, position)
+	if m.SetStickerPositionInSetFunc != nil {
+		return m.SetStickerPositionInSetFunc(sticker, position)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeleteStickerFromSet records the call and calls DeleteStickerFromSetFunc if it is set.
+func (m *API) DeleteStickerFromSet(sticker string) (*objs.Result[bool], error) {
+	m.record("DeleteStickerFromSet", sticker)
+	if m.DeleteStickerFromSetFunc != nil {
+		return m.DeleteStickerFromSetFunc(sticker)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetStickerSetThumb records the call and calls SetStickerSetThumbFunc if it is set.
+func (m *API) SetStickerSetThumb(name string, thumb string, userId int, file *objs.InputFile) (*objs.Result[bool], error) {
+	m.record("SetStickerSetThumb", name, thumb, userId, file)
+	if m.SetStickerSetThumbFunc != nil {
+		return m.SetStickerSetThumbFunc(name, thumb, userId, file)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeleteStickerSet records the call and calls DeleteStickerSetFunc if it is set.
+func (m *API) DeleteStickerSet(name string) (*objs.Result[bool], error) {
+	m.record("DeleteStickerSet", name)
+	if m.DeleteStickerSetFunc != nil {
+		return m.DeleteStickerSetFunc(name)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetStickerSetTitle records the call and calls SetStickerSetTitleFunc if it is set.
+func (m *API) SetStickerSetTitle(name string, title string) (*objs.Result[bool], error) {
+	m.record("SetStickerSetTitle", name, title)
+	if m.SetStickerSetTitleFunc != nil {
+		return m.SetStickerSetTitleFunc(name, title)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetStickerEmojiList records the call and calls SetStickerEmojiListFunc if it is set.
+func (m *API) SetStickerEmojiList(sticker string, emojiLst []string) (*objs.Result[bool], error) {
+	m.record("SetStickerEmojiList", sticker, emojiLst)
+	if m.SetStickerEmojiListFunc != nil {
+		return m.SetStickerEmojiListFunc(sticker, emojiLst)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetStickerKeywords records the call and calls SetStickerKeywordsFunc if it is set.
+func (m *API) SetStickerKeywords(sticker string, keywords []string) (*objs.Result[bool], error) {
+	m.record("SetStickerKeywords", sticker, keywords)
+	if m.SetStickerKeywordsFunc != nil {
+		return m.SetStickerKeywordsFunc(sticker, keywords)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetStickerMaskPosition records the call and calls SetStickerMaskPositionFunc if it is set.
+func (m *API) SetStickerMaskPosition(sticker string, maskPosition *objs.MaskPosition) (*objs.Result[bool], error) {
+	m.record("SetStickerMaskPosition", sticker, maskPosition)
+	if m.SetStickerMaskPositionFunc != nil {
+		return m.SetStickerMaskPositionFunc(sticker, maskPosition)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// AnswerInlineQuery records the call and calls AnswerInlineQueryFunc if it is set.
+func (m *API) AnswerInlineQuery(inlineQueryId string, results []objs.InlineQueryResult, cacheTime int, isPersonal bool, nextOffset string, button *objs.InlineQueryResultsButton) (*objs.Result[bool], error) {
+	m.record("AnswerInlineQuery", inlineQueryId, results, cacheTime, isPersonal, nextOffset, button)
+	if m.AnswerInlineQueryFunc != nil {
+		return m.AnswerInlineQueryFunc(inlineQueryId, results, cacheTime, isPersonal, nextOffset, button)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SendInvoice records the call and calls SendInvoiceFunc if it is set.
+func (m *API) SendInvoice(chatIdInt int, chatIdString string, title string, description string, payload string, providerToken string, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, startParameter string, providerData string, photoURL string, photoSize int, photoWidth int, photoHeight int, needName bool, needPhoneNumber bool, needEmail bool, needSippingAddress bool, sendPhoneNumberToProvider bool, sendEmailToProvider bool, isFlexible bool, disableNotif bool, replyToMessageId int, messageThreadId int, allowSendingWithoutReply bool, replyMarkup objs.InlineKeyboardMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendInvoice", chatIdInt, chatIdString, title, description, payload, providerToken, currency, prices, maxTipAmount, suggestedTipAmounts, startParameter, providerData, photoURL, photoSize, photoWidth, photoHeight, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif, replyToMessageId, messageThreadId, allowSendingWithoutReply, replyMarkup)
+	if m.SendInvoiceFunc != nil {
+		return m.SendInvoiceFunc(chatIdInt, chatIdString, title, description, payload, providerToken, currency, prices, maxTipAmount, suggestedTipAmounts, startParameter, providerData, photoURL, photoSize, photoWidth, photoHeight, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible, disableNotif, replyToMessageId, messageThreadId, allowSendingWithoutReply, replyMarkup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// CreateInvoiceLink records the call and calls CreateInvoiceLinkFunc if it is set.
+func (m *API) CreateInvoiceLink(title string, description string, payload string, providerToken string, currency string, prices []objs.LabeledPrice, maxTipAmount int, suggestedTipAmounts []int, providerData string, photoURL string, photoSize int, photoWidth int, photoHeight int, needName bool, needPhoneNumber bool, needEmail bool, needSippingAddress bool, sendPhoneNumberToProvider bool, sendEmailToProvider bool, isFlexible bool) (*objs.Result[string], error) {
+	m.record("CreateInvoiceLink", title, description, payload, providerToken, currency, prices, maxTipAmount, suggestedTipAmounts, providerData, photoURL, photoSize, photoWidth, photoHeight, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible)
+	if m.CreateInvoiceLinkFunc != nil {
+		return m.CreateInvoiceLinkFunc(title, description, payload, providerToken, currency, prices, maxTipAmount, suggestedTipAmounts, providerData, photoURL, photoSize, photoWidth, photoHeight, needName, needPhoneNumber, needEmail, needSippingAddress, sendPhoneNumberToProvider, sendEmailToProvider, isFlexible)
+	}
+	return &objs.Result[string]{Ok: true}, nil
+}
+
+// AnswerShippingQuery records the call and calls AnswerShippingQueryFunc if it is set.
+func (m *API) AnswerShippingQuery(shippingQueryId string, ok bool, shippingOptions []objs.ShippingOption, errorMessage string) (*objs.Result[bool], error) {
+	m.record("AnswerShippingQuery", shippingQueryId, ok, shippingOptions, errorMessage)
+	if m.AnswerShippingQueryFunc != nil {
+		return m.AnswerShippingQueryFunc(shippingQueryId, ok, shippingOptions, errorMessage)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// AnswerPreCheckoutQuery records the call and calls AnswerPreCheckoutQueryFunc if it is set.
+func (m *API) AnswerPreCheckoutQuery(preCheckoutQueryId string, ok bool, errorMessage string) (*objs.Result[bool], error) {
+	m.record("AnswerPreCheckoutQuery", preCheckoutQueryId, ok, errorMessage)
+	if m.AnswerPreCheckoutQueryFunc != nil {
+		return m.AnswerPreCheckoutQueryFunc(preCheckoutQueryId, ok, errorMessage)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// CopyMessage records the call and calls CopyMessageFunc if it is set.
+func (m *API) CopyMessage(chatIdInt int, fromChatIdInt int, chatIdString string, fromChatIdString string, messageId int, disableNotif bool, caption string, parseMode string, replyTo int, allowSendingWihtoutReply bool, ProtectContent bool, replyMarkUp objs.ReplyMarkup, captionEntities []objs.MessageEntity) (*objs.Result[*objs.Message], error) {
+	m.record("CopyMessage", chatIdInt, fromChatIdInt, chatIdString, fromChatIdString, messageId, disableNotif, caption, parseMode, replyTo, allowSendingWihtoutReply, ProtectContent, replyMarkUp, captionEntities)
+	if m.CopyMessageFunc != nil {
+		return m.CopyMessageFunc(chatIdInt, fromChatIdInt, chatIdString, fromChatIdString, messageId, disableNotif, caption, parseMode, replyTo, allowSendingWihtoutReply, ProtectContent, replyMarkUp, captionEntities)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SetPassportDataErrors records the call and calls SetPassportDataErrorsFunc if it is set.
+func (m *API) SetPassportDataErrors(userId int, errors []objs.PassportElementError) (*objs.Result[bool], error) {
+	m.record("SetPassportDataErrors", userId, errors)
+	if m.SetPassportDataErrorsFunc != nil {
+		return m.SetPassportDataErrorsFunc(userId, errors)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SendGame records the call and calls SendGameFunc if it is set.
+func (m *API) SendGame(chatId int, gameShortName string, disableNotif bool, replyTo int, allowSendingWithoutReply bool, replyMarkup objs.ReplyMarkup) (*objs.Result[*objs.Message], error) {
+	m.record("SendGame", chatId, gameShortName, disableNotif, replyTo, allowSendingWithoutReply, replyMarkup)
+	if m.SendGameFunc != nil {
+		return m.SendGameFunc(chatId, gameShortName, disableNotif, replyTo, allowSendingWithoutReply, replyMarkup)
+	}
+	return &objs.Result[*objs.Message]{Ok: true}, nil
+}
+
+// SetGameScore records the call and calls SetGameScoreFunc if it is set.
+func (m *API) SetGameScore(userId int, score int, force bool, disableEditMessage bool, chatId int, messageId int, inlineMessageId string) (*objs.Result[json.RawMessage], error) {
+	m.record("SetGameScore", userId, score, force, disableEditMessage, chatId, messageId, inlineMessageId)
+	if m.SetGameScoreFunc != nil {
+		return m.SetGameScoreFunc(userId, score, force, disableEditMessage, chatId, messageId, inlineMessageId)
+	}
+	return &objs.Result[json.RawMessage]{Ok: true}, nil
+}
+
+// GetGameHighScores records the call and calls GetGameHighScoresFunc if it is set.
+func (m *API) GetGameHighScores(userId int, chatId int, messageId int, inlineMessageId string) (*objs.Result[[]*objs.GameHighScore], error) {
+	m.record("GetGameHighScores", userId, chatId, messageId, inlineMessageId)
+	if m.GetGameHighScoresFunc != nil {
+		return m.GetGameHighScoresFunc(userId, chatId, messageId, inlineMessageId)
+	}
+	return &objs.Result[[]*objs.GameHighScore]{Ok: true}, nil
+}
+
+// GetWebhookInfo records the call and calls GetWebhookInfoFunc if it is set.
+func (m *API) GetWebhookInfo() (*objs.Result[*objs.WebhookInfo], error) {
+	m.record("GetWebhookInfo")
+	if m.GetWebhookInfoFunc != nil {
+		return m.GetWebhookInfoFunc()
+	}
+	return &objs.Result[*objs.WebhookInfo]{Ok: true}, nil
+}
+
+// SetWebhook records the call and calls SetWebhookFunc if it is set.
+func (m *API) SetWebhook(url string, ip string, maxCnc int, allowedUpdates []string, dropPendingUpdates bool, keyFile *objs.InputFile) (*objs.Result[bool], error) {
+	m.record("SetWebhook", url, ip, maxCnc, allowedUpdates, dropPendingUpdates, keyFile)
+	if m.SetWebhookFunc != nil {
+		return m.SetWebhookFunc(url, ip, maxCnc, allowedUpdates, dropPendingUpdates, keyFile)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeleteWebhook records the call and calls DeleteWebhookFunc if it is set.
+func (m *API) DeleteWebhook(dropPendingUpdates bool) (*objs.Result[bool], error) {
+	m.record("DeleteWebhook", dropPendingUpdates)
+	if m.DeleteWebhookFunc != nil {
+		return m.DeleteWebhookFunc(dropPendingUpdates)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// GetCustomEmojiStickers records the call and calls GetCustomEmojiStickersFunc if it is set.
+func (m *API) GetCustomEmojiStickers(customEmojiIds []string) (*objs.Result[[]*objs.Sticker], error) {
+	m.record("GetCustomEmojiStickers", customEmojiIds)
+	if m.GetCustomEmojiStickersFunc != nil {
+		return m.GetCustomEmojiStickersFunc(customEmojiIds)
+	}
+	return &objs.Result[[]*objs.Sticker]{Ok: true}, nil
+}
+
+// AnswerWebAppQuery records the call and calls AnswerWebAppQueryFunc if it is set.
+func (m *API) AnswerWebAppQuery(webAppQueryId string, result objs.InlineQueryResult) (*objs.SentWebAppMessage, error) {
+	m.record("AnswerWebAppQuery", webAppQueryId, result)
+	if m.AnswerWebAppQueryFunc != nil {
+		return m.AnswerWebAppQueryFunc(webAppQueryId, result)
+	}
+	return nil, nil
+}
+
+// GetChatMenuButton records the call and calls GetChatMenuButtonFunc if it is set.
+func (m *API) GetChatMenuButton(chatId int64) (*objs.Result[*objs.MenuButton], error) {
+	m.record("GetChatMenuButton", chatId)
+	if m.GetChatMenuButtonFunc != nil {
+		return m.GetChatMenuButtonFunc(chatId)
+	}
+	return &objs.Result[*objs.MenuButton]{Ok: true}, nil
+}
+
+// SetChatMenuButton records the call and calls SetChatMenuButtonFunc if it is set.
+func (m *API) SetChatMenuButton(chatId int64, menuButton *objs.MenuButton) (*objs.Result[bool], error) {
+	m.record("SetChatMenuButton", chatId, menuButton)
+	if m.SetChatMenuButtonFunc != nil {
+		return m.SetChatMenuButtonFunc(chatId, menuButton)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// GetForumTopicIconStickers records the call and calls GetForumTopicIconStickersFunc if it is set.
+func (m *API) GetForumTopicIconStickers() (*objs.Result[[]*objs.Sticker], error) {
+	m.record("GetForumTopicIconStickers")
+	if m.GetForumTopicIconStickersFunc != nil {
+		return m.GetForumTopicIconStickersFunc()
+	}
+	return &objs.Result[[]*objs.Sticker]{Ok: true}, nil
+}
+
+// CreateForumTopic records the call and calls CreateForumTopicFunc if it is set.
+func (m *API) CreateForumTopic(chatIdInt int, chatIdString string, name string, iconCustomEmojiId string, iconColor int) (*objs.Result[*objs.ForumTopic], error) {
+	m.record("CreateForumTopic", chatIdInt, chatIdString, name, iconCustomEmojiId, iconColor)
+	if m.CreateForumTopicFunc != nil {
+		return m.CreateForumTopicFunc(chatIdInt, chatIdString, name, iconCustomEmojiId, iconColor)
+	}
+	return &objs.Result[*objs.ForumTopic]{Ok: true}, nil
+}
+
+// EditForumTopic records the call and calls EditForumTopicFunc if it is set.
+func (m *API) EditForumTopic(chatIdInt int, chatIdString string, name string, iconCustomEmojiId string, messageThreadId int) (*objs.Result[bool], error) {
+	m.record("EditForumTopic", chatIdInt, chatIdString, name, iconCustomEmojiId, messageThreadId)
+	if m.EditForumTopicFunc != nil {
+		return m.EditForumTopicFunc(chatIdInt, chatIdString, name, iconCustomEmojiId, messageThreadId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// CloseForumTopic records the call and calls CloseForumTopicFunc if it is set.
+func (m *API) CloseForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
+	m.record("CloseForumTopic", chatIdInt, chatIdString, messageThreadId)
+	if m.CloseForumTopicFunc != nil {
+		return m.CloseForumTopicFunc(chatIdInt, chatIdString, messageThreadId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// ReopenForumTopic records the call and calls ReopenForumTopicFunc if it is set.
+func (m *API) ReopenForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
+	m.record("ReopenForumTopic", chatIdInt, chatIdString, messageThreadId)
+	if m.ReopenForumTopicFunc != nil {
+		return m.ReopenForumTopicFunc(chatIdInt, chatIdString, messageThreadId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// DeleteForumTopic records the call and calls DeleteForumTopicFunc if it is set.
+func (m *API) DeleteForumTopic(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
+	m.record("DeleteForumTopic", chatIdInt, chatIdString, messageThreadId)
+	if m.DeleteForumTopicFunc != nil {
+		return m.DeleteForumTopicFunc(chatIdInt, chatIdString, messageThreadId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// UnpinAllForumTopicMessages records the call and calls UnpinAllForumTopicMessagesFunc if it is set.
+func (m *API) UnpinAllForumTopicMessages(chatIdInt int, chatIdString string, messageThreadId int) (*objs.Result[bool], error) {
+	m.record("UnpinAllForumTopicMessages", chatIdInt, chatIdString, messageThreadId)
+	if m.UnpinAllForumTopicMessagesFunc != nil {
+		return m.UnpinAllForumTopicMessagesFunc(chatIdInt, chatIdString, messageThreadId)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// EditGeneralForumTopic records the call and calls EditGeneralForumTopicFunc if it is set.
+func (m *API) EditGeneralForumTopic(chatIdInt int, chatIdString string, name string) (*objs.Result[bool], error) {
+	m.record("EditGeneralForumTopic", chatIdInt, chatIdString, name)
+	if m.EditGeneralForumTopicFunc != nil {
+		return m.EditGeneralForumTopicFunc(chatIdInt, chatIdString, name)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// CloseGeneralForumTopic records the call and calls CloseGeneralForumTopicFunc if it is set.
+func (m *API) CloseGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("CloseGeneralForumTopic", chatIdInt, chatIdString)
+	if m.CloseGeneralForumTopicFunc != nil {
+		return m.CloseGeneralForumTopicFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// ReopenGeneralForumTopic records the call and calls ReopenGeneralForumTopicFunc if it is set.
+func (m *API) ReopenGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("ReopenGeneralForumTopic", chatIdInt, chatIdString)
+	if m.ReopenGeneralForumTopicFunc != nil {
+		return m.ReopenGeneralForumTopicFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// HideGeneralForumTopic records the call and calls HideGeneralForumTopicFunc if it is set.
+func (m *API) HideGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("HideGeneralForumTopic", chatIdInt, chatIdString)
+	if m.HideGeneralForumTopicFunc != nil {
+		return m.HideGeneralForumTopicFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// UnhideGeneralForumTopic records the call and calls UnhideGeneralForumTopicFunc if it is set.
+func (m *API) UnhideGeneralForumTopic(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("UnhideGeneralForumTopic", chatIdInt, chatIdString)
+	if m.UnhideGeneralForumTopicFunc != nil {
+		return m.UnhideGeneralForumTopicFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// UnpinAllGeneralForumTopicMessages records the call and calls UnpinAllGeneralForumTopicMessagesFunc if it is set.
+func (m *API) UnpinAllGeneralForumTopicMessages(chatIdInt int, chatIdString string) (*objs.Result[bool], error) {
+	m.record("UnpinAllGeneralForumTopicMessages", chatIdInt, chatIdString)
+	if m.UnpinAllGeneralForumTopicMessagesFunc != nil {
+		return m.UnpinAllGeneralForumTopicMessagesFunc(chatIdInt, chatIdString)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetMyDescription records the call and calls SetMyDescriptionFunc if it is set.
+func (m *API) SetMyDescription(description string, languageCode string) (*objs.Result[bool], error) {
+	m.record("SetMyDescription", description, languageCode)
+	if m.SetMyDescriptionFunc != nil {
+		return m.SetMyDescriptionFunc(description, languageCode)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// SetMyShortDescription records the call and calls SetMyShortDescriptionFunc if it is set.
+func (m *API) SetMyShortDescription(description string, languageCode string) (*objs.Result[bool], error) {
+	m.record("SetMyShortDescription", description, languageCode)
+	if m.SetMyShortDescriptionFunc != nil {
+		return m.SetMyShortDescriptionFunc(description, languageCode)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// GetMyDescription records the call and calls GetMyDescriptionFunc if it is set.
+func (m *API) GetMyDescription(languageCode string) (*objs.Result[*objs.BotDescription], error) {
+	m.record("GetMyDescription", languageCode)
+	if m.GetMyDescriptionFunc != nil {
+		return m.GetMyDescriptionFunc(languageCode)
+	}
+	return &objs.Result[*objs.BotDescription]{Ok: true}, nil
+}
+
+// GetMyShortDescription records the call and calls GetMyShortDescriptionFunc if it is set.
+func (m *API) GetMyShortDescription(languageCode string) (*objs.Result[*objs.BotShortDescription], error) {
+	m.record("GetMyShortDescription", languageCode)
+	if m.GetMyShortDescriptionFunc != nil {
+		return m.GetMyShortDescriptionFunc(languageCode)
+	}
+	return &objs.Result[*objs.BotShortDescription]{Ok: true}, nil
+}
+
+// SetMyName records the call and calls SetMyNameFunc if it is set.
+func (m *API) SetMyName(name string, languageCode string) (*objs.Result[bool], error) {
+	m.record("SetMyName", name, languageCode)
+	if m.SetMyNameFunc != nil {
+		return m.SetMyNameFunc(name, languageCode)
+	}
+	return &objs.Result[bool]{Ok: true}, nil
+}
+
+// GetMyName records the call and calls GetMyNameFunc if it is set.
+func (m *API) GetMyName(languageCode string) (*objs.Result[*objs.BotName], error) {
+	m.record("GetMyName", languageCode)
+	if m.GetMyNameFunc != nil {
+		return m.GetMyNameFunc(languageCode)
+	}
+	return &objs.Result[*objs.BotName]{Ok: true}, nil
+}
+
+// SendCustom records the call and calls SendCustomFunc if it is set.
+func (m *API) SendCustom(methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error) {
+	m.record("SendCustom", methodName, args, MP, files)
+	if m.SendCustomFunc != nil {
+		return m.SendCustomFunc(methodName, args, MP, files...)
+	}
+	return nil, nil
+}
+
+// SendCustomCtx records the call and calls SendCustomCtxFunc if it is set.
+func (m *API) SendCustomCtx(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*os.File) ([]byte, error) {
+	m.record("SendCustomCtx", ctx, methodName, args, MP, files)
+	if m.SendCustomCtxFunc != nil {
+		return m.SendCustomCtxFunc(ctx, methodName, args, MP, files...)
+	}
+	return nil, nil
+}
+
+// SendCustomFiles records the call and calls SendCustomFilesFunc if it is set.
+func (m *API) SendCustomFiles(ctx context.Context, methodName string, args objs.MethodArguments, MP bool, files ...*objs.InputFile) ([]byte, error) {
+	m.record("SendCustomFiles", ctx, methodName, args, MP, files)
+	if m.SendCustomFilesFunc != nil {
+		return m.SendCustomFilesFunc(ctx, methodName, args, MP, files...)
+	}
+	return nil, nil
+}
+
+// DownloadTo records the call and calls DownloadToFunc if it is set.
+func (m *API) DownloadTo(fileObject *objs.File, w io.Writer, maxSize int64) (int64, error) {
+	m.record("DownloadTo", fileObject, w, maxSize)
+	if m.DownloadToFunc != nil {
+		return m.DownloadToFunc(fileObject, w, maxSize)
+	}
+	return 0, nil
+}
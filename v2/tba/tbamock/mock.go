@@ -0,0 +1,118 @@
+/*
+Package tbamock contains a recording mock of tba.API interface. The mock records the arguments of every call and returns successful empty results by default.
+The result of each method can be changed by setting the function with the same name and "Func" suffix :
+
+	api := tbamock.New(cfg)
+	api.SendMessageFunc = func(chatIdInt int, ...) (*objs.Result[*objs.Message], error) {
+		return nil, errors.New("failed")
+	}
+	bot, _ := telego.NewBotWithAPI(cfg, api)
+	//Run the code which uses the bot.
+	calls := api.Calls("SendMessage")
+*/
+package tbamock
+
+//go:generate go run ../internal/mockgen -in ../api.go -out api_mock.go
+
+import (
+	"context"
+	"sync"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/parser"
+	"github.com/SakoDroid/telego/v2/tba"
+)
+
+// Call is a recorded call to one of the methods of the mock.
+type Call struct {
+	Method string
+	//Args are the arguments of the call in the same order as the method parameters. Variadic arguments are recorded as a slice.
+	Args []any
+}
+
+// API is a recording mock of tba.API. Use "New" to create it.
+type API struct {
+	funcs
+	mx                sync.Mutex
+	calls             []Call
+	updateChannel     *chan *objs.Update
+	chatUpdateChannel *chan *objs.ChatUpdate
+	updateParser      *parser.UpdateParser
+}
+
+var _ tba.API = &API{}
+
+/*New creates a new mock. The update channels and the update parser of the mock are created just like the real api, so handlers and middlewares of the bot work with the mock too.*/
+func New(cfg *configs.BotConfigs) *API {
+	uc := make(chan *objs.Update)
+	cu := make(chan *objs.ChatUpdate)
+	return &API{
+		updateChannel:     &uc,
+		chatUpdateChannel: &cu,
+		updateParser:      parser.CreateUpdateParser(&uc, &cu, cfg, logger.InitTheLogger(cfg)),
+	}
+}
+
+// WithContext returns the mock itself. Calls are not recorded.
+func (m *API) WithContext(ctx context.Context) tba.API {
+	return m
+}
+
+// GetUpdateChannel returns the update channel of the mock.
+func (m *API) GetUpdateChannel() *chan *objs.Update {
+	return m.updateChannel
+}
+
+// GetChatUpdateChannel returns the chat update channel of the mock.
+func (m *API) GetChatUpdateChannel() *chan *objs.ChatUpdate {
+	return m.chatUpdateChannel
+}
+
+// GetUpdateParser returns the update parser of the mock. Updates can be passed to the bot by calling "ExecuteChain" method of the parser.
+func (m *API) GetUpdateParser() *parser.UpdateParser {
+	return m.updateParser
+}
+
+// Calls returns the recorded calls. If any methods are given, only the calls to those methods are returned.
+func (m *API) Calls(methods ...string) []Call {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+	out := make([]Call, 0, len(m.calls))
+	for _, c := range m.calls {
+		if len(methods) == 0 {
+			out = append(out, c)
+			continue
+		}
+		for _, method := range methods {
+			if c.Method == method {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+// LastCall returns the last call to the given method. The second returned value is false if the method has not been called.
+func (m *API) LastCall(method string) (Call, bool) {
+	calls := m.Calls(method)
+	if len(calls) == 0 {
+		return Call{}, false
+	}
+	return calls[len(calls)-1], true
+}
+
+// Reset removes all the recorded calls.
+func (m *API) Reset() {
+	m.mx.Lock()
+	m.calls = nil
+	m.mx.Unlock()
+}
+
+func (m *API) record(method string, args ...any) {
+	m.mx.Lock()
+	m.calls = append(m.calls, Call{Method: method, Args: args})
+	m.mx.Unlock()
+}
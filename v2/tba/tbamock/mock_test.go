@@ -0,0 +1,69 @@
+package tbamock_test
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	telego "github.com/SakoDroid/telego/v2"
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/tba/tbamock"
+)
+
+func testConfigs(t *testing.T) *configs.BotConfigs {
+	dir := t.TempDir()
+	cfg := configs.DefaultConfigName("123456:TEST-TOKEN", filepath.Join(dir, "configs.json"))
+	cfg.LogFileAddress = filepath.Join(dir, "bot.log")
+	return cfg
+}
+
+func TestMockRecordsCalls(t *testing.T) {
+	cfg := testConfigs(t)
+	api := tbamock.New(cfg)
+	bot, err := telego.NewBotWithAPI(cfg, api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := bot.SendMessage(12, "hello", "HTML", 3, true, false, nil)
+	if err != nil || !res.Ok {
+		t.Fatalf("expected a successful default result, got %v, %v", res, err)
+	}
+	call, ok := api.LastCall("SendMessage")
+	if !ok {
+		t.Fatalf("SendMessage was not recorded. Calls : %v", api.Calls())
+	}
+	if call.Args[0] != 12 || call.Args[2] != "hello" || call.Args[3] != "HTML" || call.Args[9] != 3 {
+		t.Fatalf("unexpected arguments : %v", call.Args)
+	}
+	api.Reset()
+	if len(api.Calls()) != 0 {
+		t.Fatal("calls were not removed by Reset")
+	}
+}
+
+func TestMockFuncOverridesResult(t *testing.T) {
+	cfg := testConfigs(t)
+	api := tbamock.New(cfg)
+	failure := errors.New("failed")
+	api.GetChatFunc = func(chatIdInt int, chatIdString string) (*objs.Result[*objs.Chat], error) {
+		if chatIdInt == 1 {
+			return &objs.Result[*objs.Chat]{Ok: true, Result: &objs.Chat{Id: 1, Title: "Test"}}, nil
+		}
+		return nil, failure
+	}
+	bot, err := telego.NewBotWithAPI(cfg, api)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := bot.GetChatManagerById(1).GetChatInfo()
+	if err != nil || res.Result.Title != "Test" {
+		t.Fatalf("unexpected result : %v, %v", res, err)
+	}
+	if _, err := bot.GetChatManagerById(2).GetChatInfo(); err != failure {
+		t.Fatalf("expected the error of GetChatFunc, got %v", err)
+	}
+	if n := len(api.Calls("GetChat")); n != 2 {
+		t.Fatalf("expected 2 GetChat calls, got %d", n)
+	}
+}
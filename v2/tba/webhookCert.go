@@ -0,0 +1,205 @@
+package tba
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"math/big"
+	"net"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	cfg "github.com/SakoDroid/telego/v2/configs"
+)
+
+const (
+	/*The validity duration of the generated certificates.*/
+	certValidity = 365 * 24 * time.Hour
+	/*Certificates are renewed when they expire in less than this duration.*/
+	certRenewBefore = 30 * 24 * time.Hour
+	/*The maximum duration between checking the expiry of the certificate.*/
+	certCheckInterval = 12 * time.Hour
+)
+
+/*certManager keeps the self signed certificate of the webhook and renews it before it expires.*/
+type certManager struct {
+	mx   sync.RWMutex
+	whc  *cfg.WebHookConfigs
+	cert *tls.Certificate
+}
+
+/*
+Loads the certificate files of the webhook configs. If the files don't exist or the certificate is about to expire, a new certificate is generated and saved in the files.
+The returned bool is true if a new certificate has been generated.
+*/
+func loadCertManager(whc *cfg.WebHookConfigs) (*certManager, bool, error) {
+	cm := &certManager{whc: whc}
+	cert, err := tls.LoadX509KeyPair(whc.CertFile, whc.KeyFile)
+	if err == nil {
+		cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+		if err != nil {
+			return nil, false, err
+		}
+		cm.cert = &cert
+		if !cm.needsRenewal() {
+			return cm, false, nil
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return nil, false, err
+	}
+	if err := cm.renew(); err != nil {
+		return nil, false, err
+	}
+	return cm, true, nil
+}
+
+func (cm *certManager) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cm.mx.RLock()
+	defer cm.mx.RUnlock()
+	return cm.cert, nil
+}
+
+/*Returns the time which the certificate should be renewed at.*/
+func (cm *certManager) renewalTime() time.Time {
+	cm.mx.RLock()
+	defer cm.mx.RUnlock()
+	if cm.cert == nil || cm.cert.Leaf == nil {
+		return time.Time{}
+	}
+	return cm.cert.Leaf.NotAfter.Add(-certRenewBefore)
+}
+
+func (cm *certManager) needsRenewal() bool {
+	return !time.Now().Before(cm.renewalTime())
+}
+
+/*Generates a new certificate and saves it in the certificate and key files.*/
+func (cm *certManager) renew() error {
+	certPEM, keyPEM, err := generateCertificate(cm.whc)
+	if err != nil {
+		return err
+	}
+	cert, err := tls.X509KeyPair(certPEM, keyPEM)
+	if err != nil {
+		return err
+	}
+	cert.Leaf, err = x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(cm.whc.KeyFile, keyPEM, 0600); err != nil {
+		return err
+	}
+	if err := os.WriteFile(cm.whc.CertFile, certPEM, 0644); err != nil {
+		return err
+	}
+	cm.mx.Lock()
+	cm.cert = &cert
+	cm.mx.Unlock()
+	return nil
+}
+
+/*
+Calls the given function after renewing the certificate each time, until stop is closed.
+*/
+func (cm *certManager) startRenewalRoutine(stop <-chan struct{}, onRenew func() error, onError func(err error)) {
+	for {
+		wait := time.Until(cm.renewalTime())
+		if wait > certCheckInterval {
+			wait = certCheckInterval
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if !cm.needsRenewal() {
+			continue
+		}
+		err := cm.renew()
+		if err == nil && onRenew != nil {
+			err = onRenew()
+		}
+		if err != nil {
+			onError(err)
+			//Trying again later.
+			timer = time.NewTimer(time.Hour)
+			select {
+			case <-stop:
+				timer.Stop()
+				return
+			case <-timer.C:
+			}
+		}
+	}
+}
+
+/*Generates a self signed certificate for the host (or IP address) of the webhook url and returns the PEM encoded certificate and private key.*/
+func generateCertificate(whc *cfg.WebHookConfigs) ([]byte, []byte, error) {
+	u, err := url.Parse(whc.URL)
+	if err != nil {
+		return nil, nil, err
+	}
+	host := u.Hostname()
+	if host == "" {
+		return nil, nil, errors.New("webhook : the url has no host")
+	}
+	var key crypto.Signer
+	switch strings.ToUpper(whc.CertKeyType) {
+	case "", "RSA":
+		key, err = rsa.GenerateKey(rand.Reader, 2048)
+	case "ECDSA":
+		key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	default:
+		return nil, nil, errors.New("webhook : unknown certificate key type " + whc.CertKeyType)
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+	if err != nil {
+		return nil, nil, err
+	}
+	now := time.Now()
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: host},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(certValidity),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		template.IPAddresses = append(template.IPAddresses, ip)
+	} else {
+		template.DNSNames = append(template.DNSNames, host)
+	}
+	if ip := net.ParseIP(whc.IP); ip != nil && whc.IP != host {
+		template.IPAddresses = append(template.IPAddresses, ip)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		return nil, nil, err
+	}
+	keyDer, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, nil, err
+	}
+	certPEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDer})
+	return certPEM, keyPEM, nil
+}
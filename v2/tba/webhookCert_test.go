@@ -0,0 +1,90 @@
+package tba
+
+import (
+	"crypto/ecdsa"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+)
+
+func TestWebhookSelfSignedCertificate(t *testing.T) {
+	dir := t.TempDir()
+	cfg := cfgs.Default("token")
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{
+		URL:         "https://203.0.113.7:8443",
+		SelfSigned:  true,
+		CertKeyType: "ECDSA",
+		CertFile:    filepath.Join(dir, "cert.pem"),
+		KeyFile:     filepath.Join(dir, "key.pem"),
+	}
+	if !cfg.Check() {
+		t.Fatal("config check failed")
+	}
+	wh := NewWebhook(cfg, nil, nil)
+	created, err := wh.LoadCertificate()
+	if err != nil || !created {
+		t.Fatalf("expected a new certificate, got %v, %v", created, err)
+	}
+	leaf := wh.certs.cert.Leaf
+	if _, ok := leaf.PublicKey.(*ecdsa.PublicKey); !ok {
+		t.Fatalf("expected an ecdsa key, got %T", leaf.PublicKey)
+	}
+	if len(leaf.IPAddresses) != 1 || !leaf.IPAddresses[0].Equal(net.ParseIP("203.0.113.7")) || leaf.Subject.CommonName != "203.0.113.7" {
+		t.Fatalf("certificate is not issued for the webhook ip : %v %s", leaf.IPAddresses, leaf.Subject.CommonName)
+	}
+
+	//The saved certificate should be reused.
+	created, err = wh.LoadCertificate()
+	if err != nil || created {
+		t.Fatalf("expected the saved certificate to be loaded, got %v, %v", created, err)
+	}
+	if !wh.certs.cert.Leaf.Equal(leaf) {
+		t.Fatal("loaded certificate is different from the saved one")
+	}
+}
+
+func TestWebhookCertificateRenewal(t *testing.T) {
+	dir := t.TempDir()
+	whc := &cfgs.WebHookConfigs{
+		URL:        "https://example.com/bot",
+		SelfSigned: true,
+		CertFile:   filepath.Join(dir, "cert.pem"),
+		KeyFile:    filepath.Join(dir, "key.pem"),
+	}
+	cm, _, err := loadCertManager(whc)
+	if err != nil {
+		t.Fatal(err)
+	}
+	old := cm.cert.Leaf
+	if old.DNSNames[0] != "example.com" {
+		t.Fatalf("certificate is not issued for the webhook host : %v", old.DNSNames)
+	}
+	//Making the certificate expire soon.
+	cm.cert.Leaf.NotAfter = time.Now().Add(certRenewBefore + 20*time.Millisecond)
+	renewed := make(chan struct{})
+	stop := make(chan struct{})
+	defer close(stop)
+	go cm.startRenewalRoutine(stop, func() error {
+		close(renewed)
+		return nil
+	}, func(err error) {
+		t.Error(err)
+	})
+	select {
+	case <-renewed:
+	case <-time.After(3 * time.Second):
+		t.Fatal("certificate was not renewed")
+	}
+	current, _ := cm.getCertificate(nil)
+	if current.Leaf.SerialNumber.Cmp(old.SerialNumber) == 0 {
+		t.Fatal("certificate was not replaced")
+	}
+	loaded, created, err := loadCertManager(whc)
+	if err != nil || created || !loaded.cert.Leaf.Equal(current.Leaf) {
+		t.Fatalf("renewed certificate was not saved : %v, %v", created, err)
+	}
+}
@@ -0,0 +1,147 @@
+package tba
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*The default duration the webhook waits for the handlers of an update before responding to the request.*/
+const defaultReplyTimeout = time.Second
+
+/*Methods which only return true. Their result is known without asking the api server, so they return a successful result when they are sent in the webhook response.*/
+var replyMethods = map[string]bool{
+	"answerCallbackQuery":    true,
+	"answerInlineQuery":      true,
+	"answerShippingQuery":    true,
+	"answerPreCheckoutQuery": true,
+	"deleteMessage":          true,
+	"deleteMessages":         true,
+	"banChatMember":          true,
+	"unbanChatMember":        true,
+	"banChatSenderChat":      true,
+	"unbanChatSenderChat":    true,
+	"restrictChatMember":     true,
+	"promoteChatMember":      true,
+	"approveChatJoinRequest": true,
+	"declineChatJoinRequest": true,
+	"pinChatMessage":         true,
+	"unpinChatMessage":       true,
+	"unpinAllChatMessages":   true,
+}
+
+/*The results returned for the methods which are sent in the webhook response. The result of the methods which don't return true is not available.*/
+var (
+	replyResult        = []byte(`{"ok":true,"result":true}`)
+	unknownReplyResult = []byte(`{"ok":true,"result":null}`)
+)
+
+type webhookReplyKey struct{}
+
+/*
+WithWebhookReply returns a context which lets the api calls sent with it be sent in the response of the webhook request of the given update, when "ReplyInResponse" is enabled.
+Only the first call is sent in the response and only if it's sent before the handlers of the update return. The result of the methods which don't return true is not available :
+they return a successful result with an empty "Result" field, so only use it for the calls whose result is not used.
+*/
+func WithWebhookReply(ctx context.Context, update *objs.Update) context.Context {
+	return context.WithValue(ctx, webhookReplyKey{}, update)
+}
+
+/*Returns the update which the calls sent with the given context belong to, or nil if they should not be sent in a webhook response.*/
+func webhookReplyUpdate(ctx context.Context) *objs.Update {
+	update, _ := ctx.Value(webhookReplyKey{}).(*objs.Update)
+	return update
+}
+
+/*webhookReply is the reply of a webhook request which is waiting for an api call to be sent in the response.*/
+type webhookReply struct {
+	update *objs.Update
+	body   []byte
+	done   chan struct{}
+	//processed is closed when the middlewares and the handlers of the update have returned.
+	processed chan struct{}
+	//Closed is true when the reply has been filled or the response has been written.
+	closed bool
+}
+
+/*Registers a new reply for the given update.*/
+func (w *Webhook) newReply(update *objs.Update) *webhookReply {
+	r := &webhookReply{update: update, done: make(chan struct{}), processed: make(chan struct{})}
+	w.mx.Lock()
+	w.replies = append(w.replies, r)
+	w.mx.Unlock()
+	return r
+}
+
+/*
+Waits until an api call is captured for the given reply, the handlers of the update return or the timeout is reached. Returns the body of the webhook response or nil if there is no reply.
+*/
+func (w *Webhook) waitReply(r *webhookReply, timeout time.Duration) []byte {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	select {
+	case <-r.done:
+	case <-r.processed:
+	case <-timer.C:
+	}
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	r.closed = true
+	for i, pending := range w.replies {
+		if pending == r {
+			w.replies = append(w.replies[:i], w.replies[i+1:]...)
+			break
+		}
+	}
+	return r.body
+}
+
+/*
+Tries to send the given api call in the response of the webhook request of the given update. Returns true if the call has been captured, in this case the call must not be sent to the api server.
+Calls are only captured when they are sent with a context returned by "WithWebhookReply", so calls which don't belong to the update are never captured.
+*/
+func (w *Webhook) captureReply(method string, args objs.MethodArguments, update *objs.Update) bool {
+	if update == nil || !isReplyMethod(method) {
+		return false
+	}
+	bt, err := json.Marshal(args)
+	if err != nil {
+		return false
+	}
+	fields := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(bt, &fields); err != nil {
+		return false
+	}
+	fields["method"], _ = json.Marshal(method)
+	body, err := json.Marshal(fields)
+	if err != nil {
+		return false
+	}
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	for _, r := range w.replies {
+		if r.update == update && !r.closed {
+			r.closed = true
+			r.body = body
+			close(r.done)
+			return true
+		}
+	}
+	return false
+}
+
+/*Reports whether the given method can be sent in the webhook response. Methods which don't change anything (such as getChat) and chat actions are not replies.*/
+func isReplyMethod(method string) bool {
+	return method != "sendChatAction" && !strings.HasPrefix(method, "get")
+}
+
+/*Returns the result which is returned for the given method when it's sent in the webhook response.*/
+func replyResultOf(method string) []byte {
+	if replyMethods[method] {
+		return replyResult
+	}
+	return unknownReplyResult
+}
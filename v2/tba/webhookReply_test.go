@@ -0,0 +1,137 @@
+package tba
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/parser"
+)
+
+/*Creates a webhook which replies in the responses, using an api server which counts the calls it receives.*/
+func createReplyingWebhook(t *testing.T, result string, timeout time.Duration) (*BotAPIInterface, *parser.UpdateParser, *Webhook, *int32) {
+	var apiCalls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&apiCalls, 1)
+		w.Write([]byte(`{"ok":true,"result":` + result + `}`))
+	}))
+	t.Cleanup(srv.Close)
+	bai := createTestInterface(srv.URL, nil)
+	cfg := bai.botConfigs
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com", ExternalServer: true, ReplyInResponse: true, ReplyTimeout: timeout}
+	uc := make(chan *objs.Update, 10)
+	cu := make(chan *objs.ChatUpdate, 10)
+	up := parser.CreateUpdateParser(&uc, &cu, cfg, logger.InitTheLogger(cfg))
+	wh := NewWebhook(cfg, up, bai.logger)
+	bai.UseWebhookReplies(wh)
+	return bai, up, wh, &apiCalls
+}
+
+func postWebhookUpdate(wh *Webhook, update string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/", strings.NewReader(update))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	wh.ServeHTTP(rec, req)
+	return rec
+}
+
+const testMessageUpdate = `{"update_id":1,"message":{"message_id":3,"date":0,"chat":{"id":5,"type":"private"},"text":"hi"}}`
+
+func TestWebhookReplyInResponse(t *testing.T) {
+	bai, up, wh, apiCalls := createReplyingWebhook(t, `{"message_id":1,"date":0,"chat":{"id":5,"type":"private"}}`, 5*time.Second)
+	results := make(chan *objs.Result[*objs.Message], 1)
+	up.AddHandler("hi", func(update *objs.Update) {
+		api := bai.WithContext(WithWebhookReply(context.Background(), update))
+		api.SendChatAction(update.Message.Chat.Id, 0, "", "typing")
+		res, err := api.SendMessage(update.Message.Chat.Id, "", "hello", "", nil, nil, false, false, false, 0, 0, nil)
+		if err != nil {
+			t.Error(err)
+		}
+		results <- res
+	}, "all")
+
+	rec := postWebhookUpdate(wh, testMessageUpdate)
+	body := make(map[string]any)
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not json : %q", rec.Body.String())
+	}
+	if body["method"] != "sendMessage" || body["chat_id"] != float64(5) || body["text"] != "hello" {
+		t.Fatalf("unexpected response : %s", rec.Body.String())
+	}
+	if res := <-results; res == nil || !res.Ok {
+		t.Fatalf("unexpected result for the replied method : %v", res)
+	}
+	//Only sendChatAction is sent to the api server.
+	if n := atomic.LoadInt32(apiCalls); n != 1 {
+		t.Fatalf("expected 1 api call, got %d", n)
+	}
+}
+
+func TestWebhookReplyAfterHandlers(t *testing.T) {
+	bai, up, wh, apiCalls := createReplyingWebhook(t, "true", 5*time.Second)
+	sent := make(chan struct{})
+	up.AddHandler("hi", func(update *objs.Update) {
+		//The call is sent after the handler has returned.
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			bai.WithContext(WithWebhookReply(context.Background(), update)).DeleteMessage(update.Message.Chat.Id, "", update.Message.MessageId)
+			close(sent)
+		}()
+	}, "all")
+	start := time.Now()
+	rec := postWebhookUpdate(wh, testMessageUpdate)
+	//The response is written as soon as the handlers return, without waiting for the timeout.
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("the response was written after %v", elapsed)
+	}
+	if rec.Code != 200 || rec.Body.Len() != 0 {
+		t.Fatalf("expected an empty response, got %d %q", rec.Code, rec.Body.String())
+	}
+	<-sent
+	if n := atomic.LoadInt32(apiCalls); n != 1 {
+		t.Fatalf("the call sent after the response was not sent to the api server, %d api calls", n)
+	}
+}
+
+func TestWebhookReplyOnlyOwnCalls(t *testing.T) {
+	bai, up, wh, apiCalls := createReplyingWebhook(t, "true", 5*time.Second)
+	started, release := make(chan struct{}), make(chan struct{})
+	up.AddCallbackHandler("x", func(update *objs.Update) {
+		close(started)
+		<-release
+		res, err := bai.WithContext(WithWebhookReply(context.Background(), update)).AnswerCallbackQuery(update.CallbackQuery.Id, "done", "", false, 0)
+		if err != nil || res == nil || !res.Result {
+			t.Errorf("expected a true result for the replied method, got %v %v", res, err)
+		}
+	})
+	responses := make(chan *httptest.ResponseRecorder, 1)
+	go func() {
+		responses <- postWebhookUpdate(wh, `{"update_id":1,"callback_query":{"id":"42","from":{"id":5,"is_bot":false,"first_name":"a"},"message":{"message_id":3,"date":0,"chat":{"id":5,"type":"private"}},"data":"x"}}`)
+	}()
+	<-started
+	//Calls which are not sent for the update are not captured, even if they belong to it's chat.
+	if _, err := bai.DeleteMessage(5, "", 3); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(apiCalls); n != 1 {
+		t.Fatalf("the unrelated call was not sent to the api server, %d api calls", n)
+	}
+	close(release)
+	rec := <-responses
+	body := make(map[string]any)
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("response is not json : %q", rec.Body.String())
+	}
+	if body["method"] != "answerCallbackQuery" || body["callback_query_id"] != "42" {
+		t.Fatalf("unexpected response : %s", rec.Body.String())
+	}
+}
@@ -0,0 +1,112 @@
+package tba
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	cfgs "github.com/SakoDroid/telego/v2/configs"
+	"github.com/SakoDroid/telego/v2/logger"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/parser"
+)
+
+func createTestWebhook(cfg *cfgs.BotConfigs, received chan *objs.Update) *Webhook {
+	uc := make(chan *objs.Update)
+	cu := make(chan *objs.ChatUpdate)
+	botLogger := logger.InitTheLogger(cfg)
+	up := parser.CreateUpdateParser(&uc, &cu, cfg, botLogger)
+	up.AddMiddleWare(func(update *objs.Update, next func()) {
+		received <- update
+	})
+	return NewWebhook(cfg, up, botLogger)
+}
+
+func TestWebhookHandler(t *testing.T) {
+	cfg := cfgs.Default("token")
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com/hooks/bot", ExternalServer: true, SecretToken: "secret"}
+	if !cfg.Check() {
+		t.Fatal("config check failed")
+	}
+	if cfg.WebHookConfigs.URL != "https://example.com/hooks/bot" {
+		t.Fatalf("url was changed to %s", cfg.WebHookConfigs.URL)
+	}
+	received := make(chan *objs.Update, 1)
+	mux := http.NewServeMux()
+	mux.Handle("/hooks/bot", createTestWebhook(cfg, received))
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	send := func(secret string) int {
+		//The body is sent without a content length.
+		req, _ := http.NewRequest("POST", srv.URL+"/hooks/bot", struct{ *strings.Reader }{strings.NewReader(`{"update_id":3}`)})
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", secret)
+		res, err := srv.Client().Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res.Body.Close()
+		return res.StatusCode
+	}
+	if code := send("wrong"); code != 403 {
+		t.Fatalf("expected 403 for a wrong secret token, got %d", code)
+	}
+	if code := send("secret"); code != 200 {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	select {
+	case up := <-received:
+		if up.Update_id != 3 {
+			t.Fatalf("unexpected update %d", up.Update_id)
+		}
+	default:
+		t.Fatal("the update was not passed to the parser")
+	}
+}
+
+func TestWebhookStartErrors(t *testing.T) {
+	cfg := cfgs.Default("token")
+	cfg.Webhook = true
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com", CertFile: "missing.crt", KeyFile: "missing.key"}
+	if !cfg.Check() {
+		t.Fatal("config check failed")
+	}
+	wh := createTestWebhook(cfg, nil)
+	if err := wh.StartWebHook(cfg, wh.parser); err == nil {
+		t.Fatal("expected an error for missing certificate files")
+	}
+
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	cfg.WebHookConfigs = &cfgs.WebHookConfigs{URL: "https://example.com", PlainHTTP: true, Port: ln.Addr().(*net.TCPAddr).Port}
+	if !cfg.Check() {
+		t.Fatal("config check failed for plain http webhook")
+	}
+	if err := wh.StartWebHook(cfg, wh.parser); err == nil {
+		t.Fatal("expected an error for a port which is in use")
+	}
+
+	ln.Close()
+	cfg.WebHookConfigs.Port = ln.Addr().(*net.TCPAddr).Port
+	if err := wh.StartWebHook(cfg, wh.parser); err != nil {
+		t.Fatal(err)
+	}
+	defer wh.Shutdown(context.Background())
+	res, err := http.Get("http://127.0.0.1:" + strconv.Itoa(cfg.WebHookConfigs.Port) + "/other")
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Body.Close()
+	if res.StatusCode != 404 {
+		t.Fatalf("expected 404 for other paths, got %d", res.StatusCode)
+	}
+}
@@ -0,0 +1,58 @@
+package telegotest
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Call is a request which has been sent to the fake api server.
+type Call struct {
+	/*Name of the called method, for example "sendMessage".*/
+	Method string
+	/*Parameters of the request. String values are unquoted and other values (numbers, objects and arrays) are kept as raw json.*/
+	Params map[string]string
+	/*Files uploaded with the request. The key is the name of the file in the multipart form.*/
+	Files map[string][]byte
+	/*The time this call has been received.*/
+	Time time.Time
+	/*The result which has been returned to the bot for this call. It's nil if the call has failed.*/
+	Result json.RawMessage
+}
+
+// Param returns the value of the given parameter. Returns empty string if the parameter has not been sent.
+func (c *Call) Param(name string) string {
+	return c.Params[name]
+}
+
+// IntParam returns the value of the given parameter as an integer. Returns 0 if the parameter has not been sent or is not a number.
+func (c *Call) IntParam(name string) int {
+	out, _ := strconv.Atoi(c.Params[name])
+	return out
+}
+
+// Decode unmarshals the value of the given json parameter (for example "reply_markup") into v.
+func (c *Call) Decode(name string, v any) error {
+	return json.Unmarshal([]byte(c.Params[name]), v)
+}
+
+/*Converts a json object into call parameters.*/
+func paramsFromJson(body []byte) (map[string]string, error) {
+	out := make(map[string]string)
+	if len(body) == 0 {
+		return out, nil
+	}
+	raw := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(body, &raw); err != nil {
+		return nil, err
+	}
+	for key, val := range raw {
+		var str string
+		if json.Unmarshal(val, &str) == nil {
+			out[key] = str
+		} else {
+			out[key] = string(val)
+		}
+	}
+	return out, nil
+}
@@ -0,0 +1,131 @@
+package telegotest
+
+import (
+	"strconv"
+	"testing"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+WaitCall waits until a call to the given method which matches the given function is received and returns it. Calls received before the "from"th call are ignored.
+Returns nil if no such call is received before the timeout. If "match" is nil, any call to the method is accepted.
+*/
+func (s *Server) WaitCall(from int, method string, match func(c *Call) bool, timeout time.Duration) *Call {
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+	for {
+		s.mx.Lock()
+		calls := s.calls
+		changed := s.changed
+		s.mx.Unlock()
+		for i := from; i < len(calls); i++ {
+			if calls[i].Method == method && (match == nil || match(calls[i])) {
+				return calls[i]
+			}
+		}
+		from = len(calls)
+		select {
+		case <-changed:
+		case <-timer.C:
+			return nil
+		}
+	}
+}
+
+/*ExpectCall fails the test if no call to the given method which matches the given function is received before the timeout of the server. Returns the matched call.*/
+func (s *Server) ExpectCall(t testing.TB, method string, match func(c *Call) bool) *Call {
+	t.Helper()
+	c := s.WaitCall(0, method, match, s.Timeout)
+	if c == nil {
+		t.Fatalf("telegotest : expected a call to %s but it was not received. Received calls : %s", method, s.describeCalls())
+	}
+	return c
+}
+
+/*ExpectReply fails the test if the bot does not send the given text to the chat of the given update after receiving the update. Returns the sendMessage call.*/
+func (s *Server) ExpectReply(t testing.TB, update *objs.Update, text string) *Call {
+	t.Helper()
+	chat := chatOf(update)
+	if chat == nil {
+		t.Fatalf("telegotest : update %d does not belong to a chat", update.Update_id)
+	}
+	c := s.WaitCall(s.callsBefore(update), "sendMessage", func(c *Call) bool {
+		return sameChat(c.Param("chat_id"), chat) && c.Param("text") == text
+	}, s.Timeout)
+	if c == nil {
+		t.Fatalf("telegotest : expected the bot to reply %q to update %d but it did not. Received calls : %s", text, update.Update_id, s.describeCalls())
+	}
+	return c
+}
+
+/*ExpectCallbackAnswer fails the test if the bot does not answer the callback query of the given update with the given text. Returns the answerCallbackQuery call.*/
+func (s *Server) ExpectCallbackAnswer(t testing.TB, update *objs.Update, text string) *Call {
+	t.Helper()
+	if update.CallbackQuery == nil {
+		t.Fatalf("telegotest : update %d is not a callback query", update.Update_id)
+	}
+	c := s.WaitCall(s.callsBefore(update), "answerCallbackQuery", func(c *Call) bool {
+		return c.Param("callback_query_id") == update.CallbackQuery.Id && c.Param("text") == text
+	}, s.Timeout)
+	if c == nil {
+		t.Fatalf("telegotest : expected the bot to answer callback query of update %d with %q but it did not. Received calls : %s", update.Update_id, text, s.describeCalls())
+	}
+	return c
+}
+
+/*ExpectNoCall fails the test if a call to any of the given methods is received during the given duration.*/
+func (s *Server) ExpectNoCall(t testing.TB, duration time.Duration, methods ...string) {
+	t.Helper()
+	from := len(s.Calls())
+	time.Sleep(duration)
+	s.mx.Lock()
+	received := filterCalls(s.calls[from:], methods)
+	s.mx.Unlock()
+	if len(received) != 0 {
+		t.Fatalf("telegotest : expected no calls to %v but received : %s", methods, describe(received))
+	}
+}
+
+func (s *Server) callsBefore(update *objs.Update) int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.sentAt[update.Update_id]
+}
+
+func (s *Server) describeCalls() string {
+	return describe(s.Calls())
+}
+
+func describe(calls []*Call) string {
+	if len(calls) == 0 {
+		return "none"
+	}
+	out := ""
+	for _, c := range calls {
+		out += "\n\t" + c.Method + " " + strconv.Quote(c.Param("text"))
+	}
+	return out
+}
+
+func chatOf(update *objs.Update) *objs.Chat {
+	switch {
+	case update.Message != nil:
+		return update.Message.Chat
+	case update.EditedMessage != nil:
+		return update.EditedMessage.Chat
+	case update.ChannelPost != nil:
+		return update.ChannelPost.Chat
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost.Chat
+	case update.CallbackQuery != nil:
+		return update.CallbackQuery.Message.Chat
+	default:
+		return nil
+	}
+}
+
+func sameChat(chatId string, chat *objs.Chat) bool {
+	return chatId == strconv.Itoa(chat.Id) || (chat.Username != "" && chatId == "@"+chat.Username)
+}
@@ -0,0 +1,200 @@
+package telegotest
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*The message field which contains the media sent by each method.*/
+var mediaFields = map[string]string{
+	"sendPhoto":     "photo",
+	"sendVideo":     "video",
+	"sendAudio":     "audio",
+	"sendDocument":  "document",
+	"sendAnimation": "animation",
+	"sendVoice":     "voice",
+	"sendVideoNote": "video_note",
+	"sendSticker":   "sticker",
+}
+
+/*Returns the default result of the given call. Methods which are not known return true.*/
+func (s *Server) defaultResult(c *Call) (any, *objs.FailureResult) {
+	switch {
+	case c.Method == "getMe":
+		return s.Me, nil
+	case c.Method == "getWebhookInfo":
+		s.mx.Lock()
+		defer s.mx.Unlock()
+		return map[string]any{"url": "", "has_custom_certificate": false, "pending_update_count": len(s.updates)}, nil
+	case c.Method == "getFile":
+		return s.getFile(c)
+	case c.Method == "sendMediaGroup":
+		var media []map[string]any
+		if err := c.Decode("media", &media); err != nil {
+			return nil, &objs.FailureResult{ErrorCode: 400, Description: "Bad Request: can't parse media json"}
+		}
+		out := make([]map[string]any, len(media))
+		for i := range media {
+			out[i] = s.newMessage(c)
+		}
+		return out, nil
+	case c.Method == "stopPoll":
+		return s.stopPoll(c)
+	case c.Method == "copyMessage":
+		return map[string]any{"message_id": s.newMessage(c)["message_id"]}, nil
+	case strings.HasPrefix(c.Method, "editMessage"):
+		if c.Param("inline_message_id") != "" {
+			return true, nil
+		}
+		msg := map[string]any{"message_id": c.IntParam("message_id"), "date": time.Now().Unix(), "from": s.Me, "chat": chatFromParam(c.Param("chat_id"))}
+		if text := c.Param("text"); text != "" {
+			msg["text"] = text
+		}
+		if caption := c.Param("caption"); caption != "" {
+			msg["caption"] = caption
+		}
+		return msg, nil
+	case c.Method == "forwardMessage" || (strings.HasPrefix(c.Method, "send") && c.Method != "sendChatAction"):
+		return s.newMessage(c), nil
+	default:
+		return true, nil
+	}
+}
+
+func (s *Server) getFile(c *Call) (any, *objs.FailureResult) {
+	id := c.Param("file_id")
+	s.mx.Lock()
+	content, ok := s.files[id]
+	s.mx.Unlock()
+	if !ok {
+		return nil, &objs.FailureResult{ErrorCode: 400, Description: "Bad Request: wrong file_id or the file is temporarily unavailable"}
+	}
+	return &objs.File{FileId: id, FileUniqueId: id, FileSize: int64(len(content)), FilePath: "files/" + id}, nil
+}
+
+/*Closes the poll of the given message and returns it.*/
+func (s *Server) stopPoll(c *Call) (any, *objs.FailureResult) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	poll, ok := s.polls[c.IntParam("message_id")]
+	if !ok {
+		return nil, &objs.FailureResult{ErrorCode: 400, Description: "Bad Request: message with poll to stop not found"}
+	}
+	poll["is_closed"] = true
+	return poll, nil
+}
+
+/*Creates the message which is returned for the methods that send a message.*/
+func (s *Server) newMessage(c *Call) map[string]any {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.messageId++
+	msg := map[string]any{
+		"message_id": s.messageId,
+		"date":       time.Now().Unix(),
+		"from":       s.Me,
+		"chat":       chatFromParam(c.Param("chat_id")),
+	}
+	if text := c.Param("text"); text != "" {
+		msg["text"] = text
+	}
+	if caption := c.Param("caption"); caption != "" {
+		msg["caption"] = caption
+	}
+	if field, ok := mediaFields[c.Method]; ok {
+		id := c.Param(field)
+		if strings.HasPrefix(id, "attach://") {
+			id = s.addFile(c.Files[strings.TrimPrefix(id, "attach://")])
+		} else if _, exists := s.files[id]; !exists {
+			//File urls are stored as empty files.
+			id = s.addFile(nil)
+		}
+		media := map[string]any{"file_id": id, "file_unique_id": id, "file_size": len(s.files[id])}
+		if field == "photo" {
+			msg[field] = []any{media}
+		} else {
+			msg[field] = media
+		}
+	}
+	if c.Method == "sendPoll" {
+		poll := newPoll(c, s.messageId)
+		s.polls[s.messageId] = poll
+		msg["poll"] = poll
+	}
+	return msg
+}
+
+/*Returns a poll without any votes which contains the options of the given "sendPoll" call.*/
+func newPoll(c *Call, messageId int) map[string]any {
+	var texts []string
+	c.Decode("options", &texts)
+	options := make([]map[string]any, len(texts))
+	for i, text := range texts {
+		options[i] = map[string]any{"text": text, "voter_count": 0}
+	}
+	pollType := c.Param("type")
+	if pollType == "" {
+		pollType = "regular"
+	}
+	return map[string]any{
+		"id":       "poll" + strconv.Itoa(messageId),
+		"question": c.Param("question"),
+		"options":  options,
+		"type":     pollType,
+	}
+}
+
+func chatFromParam(chatId string) map[string]any {
+	if id, err := strconv.Atoi(chatId); err == nil {
+		if id > 0 {
+			return map[string]any{"id": id, "type": "private"}
+		}
+		return map[string]any{"id": id, "type": "supergroup"}
+	}
+	return map[string]any{"id": 0, "type": "channel", "username": strings.TrimPrefix(chatId, "@")}
+}
+
+/*Returns the pending updates. If there is no update, the request is held until an update is injected or the timeout of the request is reached (long polling).*/
+func (s *Server) getUpdates(w http.ResponseWriter, r *http.Request, c *Call) {
+	offset, limit := c.IntParam("offset"), c.IntParam("limit")
+	if limit <= 0 || limit > 100 {
+		limit = 100
+	}
+	deadline := time.Now().Add(time.Duration(c.IntParam("timeout")) * time.Second)
+	for {
+		s.mx.Lock()
+		if offset > 0 {
+			//Updates with an id lower than the offset are confirmed.
+			remaining := s.updates[:0]
+			for _, u := range s.updates {
+				if u.Update_id >= offset {
+					remaining = append(remaining, u)
+				}
+			}
+			s.updates = remaining
+		}
+		pending := make([]*objs.Update, 0, limit)
+		for i := 0; i < len(s.updates) && i < limit; i++ {
+			pending = append(pending, s.updates[i])
+		}
+		bt, _ := json.Marshal(pending)
+		changed := s.changed
+		s.mx.Unlock()
+		if len(pending) > 0 || !time.Now().Before(deadline) {
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"ok":true,"result":` + string(bt) + `}`))
+			return
+		}
+		select {
+		case <-changed:
+		case <-r.Context().Done():
+			return
+		case <-time.After(time.Until(deadline)):
+		}
+	}
+}
@@ -0,0 +1,312 @@
+/*
+Package telegotest provides a fake telegram bot api server for testing bots without connecting to telegram.
+
+The server runs in the same process (using httptest), records all the calls that the bot sends to it and lets the tests inject updates:
+
+	func TestHi(t *testing.T) {
+		srv := telegotest.NewServer(t)
+		bot, _ := telego.NewBot(srv.Configs())
+		bot.AddHandler("hi", func(u *objs.Update) {
+			bot.SendMessage(u.Message.Chat.Id, "hi to you too", "", 0, false, false, nil)
+		}, "private")
+		bot.Run(false)
+
+		update := srv.SendText(1, 1, "hi")
+		srv.ExpectReply(t, update, "hi to you too")
+	}
+*/
+package telegotest
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+// DefaultToken is the api key accepted by the fake server.
+const DefaultToken = "123456:TEST-TOKEN"
+
+/*
+HandlerFunc produces the response of a method. If failure is not nil, it is returned to the bot as the failure result of the method, otherwise result is returned.
+*/
+type HandlerFunc func(c *Call) (result any, failure *objs.FailureResult)
+
+// Server is a fake bot api server.
+type Server struct {
+	/*Timeout is the maximum duration the "Expect" methods wait for a call. Defaults to 3 seconds.*/
+	Timeout time.Duration
+	/*Me is the user returned by getMe.*/
+	Me        *objs.User
+	t         testing.TB
+	srv       *httptest.Server
+	token     string
+	mx        sync.Mutex
+	calls     []*Call
+	updates   []*objs.Update
+	updateId  int
+	messageId int
+	fileId    int
+	files     map[string][]byte
+	handlers  map[string]HandlerFunc
+	//polls keeps the sent polls by the ids of their messages.
+	polls map[int]map[string]any
+	//sentAt keeps the number of calls that had been received when each update was injected.
+	sentAt map[int]int
+	//changed is closed and replaced every time a call is received or an update is injected.
+	changed chan struct{}
+}
+
+/*NewServer starts a new fake api server. The server is closed when the test finishes.*/
+func NewServer(t testing.TB) *Server {
+	s := &Server{
+		Timeout:  3 * time.Second,
+		Me:       &objs.User{Id: 123456, IsBot: true, FirstName: "Test bot", Username: "test_bot"},
+		t:        t,
+		token:    DefaultToken,
+		files:    make(map[string][]byte),
+		polls:    make(map[int]map[string]any),
+		handlers: make(map[string]HandlerFunc),
+		sentAt:   make(map[int]int),
+		changed:  make(chan struct{}),
+	}
+	s.srv = httptest.NewServer(http.HandlerFunc(s.serve))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// URL returns the base url of the server.
+func (s *Server) URL() string {
+	return s.srv.URL
+}
+
+// BotAPI returns the value which should be used as "BotAPI" field of the bot configs.
+func (s *Server) BotAPI() string {
+	return s.srv.URL + "/bot"
+}
+
+// Token returns the api key accepted by this server.
+func (s *Server) Token() string {
+	return s.token
+}
+
+/*
+Configs returns bot configs which connect the bot to this server. The bot polls for updates every 10 milliseconds and the config and log files are created in a temporary directory.
+*/
+func (s *Server) Configs() *configs.BotConfigs {
+	dir := s.t.TempDir()
+	cfg := configs.DefaultConfigName(s.token, filepath.Join(dir, "configs.json"))
+	cfg.BotName = "telegotest"
+	cfg.BotAPI = s.BotAPI()
+	cfg.LogFileAddress = filepath.Join(dir, "bot.log")
+	cfg.UpdateConfigs.UpdateFrequency = 10 * time.Millisecond
+	cfg.UpdateConfigs.Timeout = 1
+	cfg.HttpClient = s.srv.Client()
+	return cfg
+}
+
+// Close shuts down the server.
+func (s *Server) Close() {
+	s.srv.Close()
+}
+
+/*
+HandleFunc sets the handler of the given method. It overrides the default response of the method.
+This can be used to return custom results or failures for any method.
+*/
+func (s *Server) HandleFunc(method string, handler HandlerFunc) {
+	s.mx.Lock()
+	s.handlers[method] = handler
+	s.mx.Unlock()
+}
+
+// Fail makes the given method always fail with the given error code and description.
+func (s *Server) Fail(method string, errorCode int, description string) {
+	s.HandleFunc(method, func(c *Call) (any, *objs.FailureResult) {
+		return nil, &objs.FailureResult{ErrorCode: errorCode, Description: description}
+	})
+}
+
+/*AddFile stores the given content as a file and returns it's file id. The file can be received by the bot using getFile and then downloaded.*/
+func (s *Server) AddFile(content []byte) string {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.addFile(content)
+}
+
+func (s *Server) addFile(content []byte) string {
+	s.fileId++
+	id := "file_" + strconv.Itoa(s.fileId)
+	s.files[id] = content
+	return id
+}
+
+/*Calls returns the calls received by the server. If any methods are given, only the calls to those methods are returned. Calls to getUpdates are not recorded.*/
+func (s *Server) Calls(methods ...string) []*Call {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return filterCalls(s.calls, methods)
+}
+
+/*PendingUpdates returns the number of the updates which have not been confirmed by the bot yet. Updates are confirmed when the bot calls getUpdates with an offset higher than their ids.*/
+func (s *Server) PendingUpdates() int {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return len(s.updates)
+}
+
+// Reset removes all the recorded calls and pending updates.
+func (s *Server) Reset() {
+	s.mx.Lock()
+	s.calls = nil
+	s.updates = nil
+	s.sentAt = make(map[int]int)
+	s.mx.Unlock()
+}
+
+func filterCalls(calls []*Call, methods []string) []*Call {
+	out := make([]*Call, 0, len(calls))
+	for _, c := range calls {
+		if len(methods) == 0 {
+			out = append(out, c)
+			continue
+		}
+		for _, m := range methods {
+			if c.Method == m {
+				out = append(out, c)
+				break
+			}
+		}
+	}
+	return out
+}
+
+/*Must be called while holding the lock.*/
+func (s *Server) notify() {
+	close(s.changed)
+	s.changed = make(chan struct{})
+}
+
+func (s *Server) serve(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/file/bot") {
+		s.serveFile(w, r)
+		return
+	}
+	prefix := "/bot" + s.token + "/"
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		writeFailure(w, &objs.FailureResult{ErrorCode: 401, Description: "Unauthorized"})
+		return
+	}
+	call, err := parseCall(strings.TrimPrefix(r.URL.Path, prefix), r)
+	if err != nil {
+		writeFailure(w, &objs.FailureResult{ErrorCode: 400, Description: "Bad Request: " + err.Error()})
+		return
+	}
+	if call.Method == "getUpdates" {
+		s.getUpdates(w, r, call)
+		return
+	}
+	s.mx.Lock()
+	handler := s.handlers[call.Method]
+	s.mx.Unlock()
+	var result any
+	var failure *objs.FailureResult
+	if handler != nil {
+		result, failure = handler(call)
+	} else {
+		result, failure = s.defaultResult(call)
+	}
+	if failure == nil {
+		call.Result, err = json.Marshal(result)
+		if err != nil {
+			failure = &objs.FailureResult{ErrorCode: 500, Description: "Internal Server Error: " + err.Error()}
+		}
+	}
+	s.mx.Lock()
+	s.calls = append(s.calls, call)
+	s.notify()
+	s.mx.Unlock()
+	if failure != nil {
+		writeFailure(w, failure)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Write([]byte(`{"ok":true,"result":` + string(call.Result) + `}`))
+}
+
+func (s *Server) serveFile(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/file/bot"+s.token+"/")
+	s.mx.Lock()
+	content, ok := s.files[strings.TrimPrefix(path, "files/")]
+	s.mx.Unlock()
+	if !ok || path == r.URL.Path {
+		http.NotFound(w, r)
+		return
+	}
+	w.Write(content)
+}
+
+func writeFailure(w http.ResponseWriter, failure *objs.FailureResult) {
+	failure.Ok = false
+	if failure.ErrorCode == 0 {
+		failure.ErrorCode = 400
+	}
+	bt, _ := json.Marshal(failure)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(failure.ErrorCode)
+	w.Write(bt)
+}
+
+func parseCall(method string, r *http.Request) (*Call, error) {
+	call := &Call{Method: method, Time: time.Now(), Files: make(map[string][]byte)}
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	switch mediaType {
+	case "multipart/form-data":
+		if err := r.ParseMultipartForm(32 << 20); err != nil {
+			return nil, err
+		}
+		call.Params = make(map[string]string)
+		for key, val := range r.MultipartForm.Value {
+			call.Params[key] = val[0]
+		}
+		for key, headers := range r.MultipartForm.File {
+			fl, err := headers[0].Open()
+			if err != nil {
+				return nil, err
+			}
+			call.Files[key], err = io.ReadAll(fl)
+			fl.Close()
+			if err != nil {
+				return nil, err
+			}
+		}
+	case "application/x-www-form-urlencoded":
+		if err := r.ParseForm(); err != nil {
+			return nil, err
+		}
+		call.Params = make(map[string]string)
+		for key, val := range r.PostForm {
+			call.Params[key] = val[0]
+		}
+	default:
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			return nil, err
+		}
+		call.Params, err = paramsFromJson(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return call, nil
+}
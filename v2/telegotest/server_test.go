@@ -0,0 +1,80 @@
+package telegotest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	telego "github.com/SakoDroid/telego/v2"
+	errs "github.com/SakoDroid/telego/v2/errors"
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+func startBot(t *testing.T, srv *Server) *telego.Bot {
+	bot, err := telego.NewBot(srv.Configs())
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	if err := bot.RunCtx(ctx, false); err != nil {
+		t.Fatal(err)
+	}
+	return bot
+}
+
+func TestReplyToHandler(t *testing.T) {
+	srv := NewServer(t)
+	bot := startBot(t, srv)
+	bot.AddHandler("hi", func(u *objs.Update) {
+		bot.SendMessage(u.Message.Chat.Id, "hi to you too", "", u.Message.MessageId, false, false, nil)
+	}, "private")
+	update := srv.SendText(10, 10, "hi")
+	call := srv.ExpectReply(t, update, "hi to you too")
+	if call.IntParam("reply_to_message_id") != update.Message.MessageId && call.Param("reply_parameters") == "" {
+		t.Error("the reply does not reference the received message")
+	}
+}
+
+func TestCallbackAnswer(t *testing.T) {
+	srv := NewServer(t)
+	bot := startBot(t, srv)
+	go func() {
+		for u := range *bot.GetUpdateChannel() {
+			if u.CallbackQuery != nil {
+				bot.AnswerCallbackQuery(u.CallbackQuery.Id, "got "+u.CallbackQuery.Data, false)
+			}
+		}
+	}()
+	update := srv.SendCallback(-100, 10, 5, "data")
+	srv.ExpectCallbackAnswer(t, update, "got data")
+}
+
+func TestUploadAndDownload(t *testing.T) {
+	srv := NewServer(t)
+	bot := startBot(t, srv)
+	res, err := bot.SendDocument(10, 0, "report", "").Send(objs.FileFromBytes("report.txt", []byte("content")), false, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	call := srv.ExpectCall(t, "sendDocument", nil)
+	if string(call.Files["report.txt"]) != "content" || call.Param("caption") != "report" {
+		t.Error("the uploaded file was not recorded")
+	}
+	data, err := bot.DownloadBytes(res.Result.Document.FileId, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "content" {
+		t.Error("wrong downloaded content :", string(data))
+	}
+}
+
+func TestFailures(t *testing.T) {
+	srv := NewServer(t)
+	bot := startBot(t, srv)
+	srv.Fail("sendMessage", 403, "Forbidden: bot was blocked by the user")
+	if _, err := bot.SendMessage(10, "hi", "", 0, false, false, nil); !errors.Is(err, errs.ErrBotBlocked) {
+		t.Error("expected ErrBotBlocked, got :", err)
+	}
+}
@@ -0,0 +1,86 @@
+package telegotest
+
+import (
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf16"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+SendUpdate queues the given update. The update is delivered to the bot on it's next getUpdates call.
+If the update id is 0, a new id is assigned to it. Returns the given update.
+*/
+func (s *Server) SendUpdate(update *objs.Update) *objs.Update {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if update.Update_id == 0 {
+		s.updateId++
+		update.Update_id = s.updateId
+	} else if update.Update_id > s.updateId {
+		s.updateId = update.Update_id
+	}
+	s.sentAt[update.Update_id] = len(s.calls)
+	s.updates = append(s.updates, update)
+	s.notify()
+	return update
+}
+
+/*
+SendText sends a text message update from the given user in the given chat. Positive chat ids are private chats and negative ones are supergroups.
+If the text starts with "/", the first word is marked as a bot command.
+*/
+func (s *Server) SendText(chatId, userId int, text string) *objs.Update {
+	msg := s.createMessage(chatId, userId)
+	msg.Text = text
+	if strings.HasPrefix(text, "/") {
+		command := strings.SplitN(text, " ", 2)[0]
+		msg.Entities = []objs.MessageEntity{{Type: "bot_command", Offset: 0, Length: len(utf16.Encode([]rune(command)))}}
+	}
+	return s.SendUpdate(&objs.Update{Message: msg})
+}
+
+/*SendCallback sends a callback query update with the given data. The query is sent by the given user from the given message in the given chat.*/
+func (s *Server) SendCallback(chatId, userId, messageId int, data string) *objs.Update {
+	s.mx.Lock()
+	s.updateId++
+	id := s.updateId
+	s.mx.Unlock()
+	msg := s.createMessage(chatId, s.Me.Id)
+	msg.MessageId = messageId
+	msg.From = s.Me
+	return s.SendUpdate(&objs.Update{
+		Update_id: id,
+		CallbackQuery: &objs.CallbackQuery{
+			Id:           "callback_" + strconv.Itoa(id),
+			From:         *createUser(userId),
+			Message:      *msg,
+			ChatInstance: strconv.Itoa(chatId),
+			Data:         data,
+		},
+	})
+}
+
+func (s *Server) createMessage(chatId, userId int) *objs.Message {
+	chat := &objs.Chat{Id: chatId, Type: "private"}
+	if chatId < 0 {
+		chat.Type = "supergroup"
+		chat.Title = "Test group"
+	}
+	s.mx.Lock()
+	s.messageId++
+	id := s.messageId
+	s.mx.Unlock()
+	return &objs.Message{
+		MessageId: id,
+		From:      createUser(userId),
+		Date:      int(time.Now().Unix()),
+		Chat:      chat,
+	}
+}
+
+func createUser(userId int) *objs.User {
+	return &objs.User{Id: userId, FirstName: "User" + strconv.Itoa(userId)}
+}
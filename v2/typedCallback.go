@@ -0,0 +1,164 @@
+package telego
+
+import (
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+The callback data of the typed buttons is "~" followed by the tag of the type, a separator and the payload. The payload is the encoded value if the separator is ":",
+or the key of the stored value if the separator is "#".
+*/
+const (
+	typedCallbackPrefix   = "~"
+	typedCallbackInline   = ":"
+	typedCallbackStored   = "#"
+	maxCallbackDataLength = 64
+)
+
+/*
+AddTypedCallbackButton adds a callback button which carries the given value. The value is encoded compactly (struct fields are encoded by their order, not their names) and if the encoded value doesn't fit
+in the callback data (64 bytes), it is kept by the bot and the button only contains a short key. Stored values are removed after "CallbackDataTTL" of the configs.
+They are kept in memory unless "CallbackDataStore" is set in the configs, so without a store the buttons which carry stored values stop working after the bot is restarted.
+
+The value is received by the handler added with "AddTypedCallbackHandler" for the type of the value :
+
+	type Item struct {
+		Id     int
+		Action string
+	}
+
+	kb.AddTypedCallbackButton("Delete", Item{Id: 42, Action: "delete"}, 1)
+
+	telego.AddTypedCallbackHandler(bot, func(u *objs.Update, item Item) {
+		//item is {42 delete}
+	})
+
+Since the fields are encoded by their order, changing the order of the fields makes the buttons which have already been sent invalid.
+
+Note : row number starts from 1. (it's not zero based). If any number lower than 1 is passed, no button will be added.
+*/
+func (in *InlineKeyboard) AddTypedCallbackButton(text string, value any, row int) error {
+	if value == nil {
+		return errors.New("typed callback value is nil")
+	}
+	payload, err := encodeTypedValue(reflect.ValueOf(value))
+	if err != nil {
+		return err
+	}
+	tag := typedCallbackTag(reflect.TypeOf(value))
+	data := typedCallbackPrefix + tag + typedCallbackInline + payload
+	if len(data) > maxCallbackDataLength {
+		key, err := in.up.StoreCallbackData(payload)
+		if err != nil {
+			return err
+		}
+		data = typedCallbackPrefix + tag + typedCallbackStored + key
+	}
+	in.AddCallbackButton(text, data, row)
+	return nil
+}
+
+/*
+AddTypedCallbackHandler adds a handler for the buttons added by "AddTypedCallbackButton" which carry a value of type T. The handler receives the decoded value.
+If the value can't be decoded (for example because the stored value has expired), the callback query is answered with an error message and the handler is not called.
+*/
+func AddTypedCallbackHandler[T any](bot *Bot, handler func(u *objs.Update, value T)) {
+	up := bot.apiInterface.GetUpdateParser()
+	tag := typedCallbackTag(reflect.TypeOf((*T)(nil)).Elem())
+	//The separator is a part of the route, so the tag of a type doesn't match the data of the types which their tag starts with it.
+	pattern := "(?s)^" + regexp.QuoteMeta(typedCallbackPrefix+tag) + "(?P<separator>[" + regexp.QuoteMeta(typedCallbackInline+typedCallbackStored) + "])(?P<payload>.*)$"
+	up.AddCallbackRegexHandler(pattern, func(u *objs.Update, params map[string]string) {
+		payload := params["payload"]
+		if params["separator"] == typedCallbackStored {
+			data, ok, err := up.LoadCallbackData(payload)
+			if err != nil {
+				bot.logger.GetRaw().Println("Unable to load the stored callback data.", u.CallbackQuery.Data, err)
+				bot.AnswerCallbackQuery(u.CallbackQuery.Id, "Unable to load the button.", false)
+				return
+			}
+			if !ok {
+				bot.AnswerCallbackQuery(u.CallbackQuery.Id, "This button has expired.", false)
+				return
+			}
+			payload = data
+		}
+		var value T
+		if err := decodeTypedValue(payload, reflect.ValueOf(&value).Elem()); err != nil {
+			bot.logger.GetRaw().Println("Unable to decode the typed callback data.", u.CallbackQuery.Data, err)
+			bot.AnswerCallbackQuery(u.CallbackQuery.Id, "Invalid button.", false)
+			return
+		}
+		handler(u, value)
+	})
+}
+
+/*Returns a short tag for the given type. Pointer types have the same tag as the types they point to.*/
+func typedCallbackTag(typ reflect.Type) string {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	h := fnv.New32a()
+	h.Write([]byte(typ.PkgPath() + "." + typ.String()))
+	return strconv.FormatUint(uint64(h.Sum32()), 36)
+}
+
+/*Encodes the value as json. Structs are encoded as an array of their exported fields, so the names of the fields are not included.*/
+func encodeTypedValue(value reflect.Value) (string, error) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return "", errors.New("typed callback value is nil")
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		bt, err := json.Marshal(value.Interface())
+		return string(bt), err
+	}
+	fields := make([]any, 0, value.NumField())
+	for i := 0; i < value.NumField(); i++ {
+		if value.Type().Field(i).IsExported() {
+			fields = append(fields, value.Field(i).Interface())
+		}
+	}
+	bt, err := json.Marshal(fields)
+	return string(bt), err
+}
+
+/*Decodes the data encoded by "encodeTypedValue" into the given settable value.*/
+func decodeTypedValue(data string, value reflect.Value) error {
+	if value.Kind() == reflect.Pointer {
+		value.Set(reflect.New(value.Type().Elem()))
+		return decodeTypedValue(data, value.Elem())
+	}
+	if value.Kind() != reflect.Struct {
+		return json.Unmarshal([]byte(data), value.Addr().Interface())
+	}
+	var fields []json.RawMessage
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		return err
+	}
+	n := 0
+	for i := 0; i < value.NumField(); i++ {
+		if !value.Type().Field(i).IsExported() {
+			continue
+		}
+		if n >= len(fields) {
+			return errors.New("typed callback data has less fields than " + value.Type().String())
+		}
+		if err := json.Unmarshal(fields[n], value.Field(i).Addr().Interface()); err != nil {
+			return err
+		}
+		n++
+	}
+	if n != len(fields) {
+		return errors.New("typed callback data has more fields than " + value.Type().String())
+	}
+	return nil
+}
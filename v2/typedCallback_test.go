@@ -0,0 +1,118 @@
+package telego_test
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	telego "github.com/SakoDroid/telego/v2"
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+type testItem struct {
+	Id     int
+	Action string
+	Note   string
+	hidden bool
+}
+
+func TestTypedCallbackButtons(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startBot(t, srv)
+	telego.AddTypedCallbackHandler(bot, func(u *objs.Update, item testItem) {
+		bot.AnswerCallbackQuery(u.CallbackQuery.Id, strconv.Itoa(item.Id)+" "+item.Action+" "+item.Note, false)
+	})
+	long := strings.Repeat("a long note ", 10)
+	kb := bot.CreateInlineKeyboard()
+	if err := kb.AddTypedCallbackButton("short", testItem{Id: 42, Action: "delete", hidden: true}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := kb.AddTypedCallbackButton("long", &testItem{Id: 7, Action: "edit", Note: long}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := bot.AdvancedMode().ASendMessage(10, "items", "", 0, 0, false, false, nil, nil, false, kb); err != nil {
+		t.Fatal(err)
+	}
+	markup := &objs.InlineKeyboardMarkup{}
+	if err := srv.ExpectCall(t, "sendMessage", nil).Decode("reply_markup", markup); err != nil {
+		t.Fatal(err)
+	}
+	buttons := markup.InlineKeyboard[0]
+	for _, button := range buttons {
+		if len(button.CallbackData) > 64 {
+			t.Fatalf("callback data is longer than 64 bytes : %q", button.CallbackData)
+		}
+	}
+
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(10, 10, 1, buttons[0].CallbackData), "42 delete ")
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(10, 10, 1, buttons[1].CallbackData), "7 edit "+long)
+	//The values which are not stored anymore can't be decoded.
+	expired := strings.Split(buttons[1].CallbackData, "#")[0] + "#unknown"
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(10, 10, 1, expired), "This button has expired.")
+	//The separator is a part of the route, so the data of other types which their tag starts with the tag of this type is not routed to it's handler.
+	other := strings.Replace(buttons[0].CallbackData, ":", "x:", 1)
+	srv.SendCallback(10, 10, 1, other)
+	srv.ExpectNoCall(t, 100*time.Millisecond, "answerCallbackQuery")
+}
+
+/*mapCallbackStore is a CallbackDataStore which can be shared between several bots.*/
+type mapCallbackStore struct {
+	mx   sync.Mutex
+	data map[string]string
+}
+
+func (ms *mapCallbackStore) Save(key, data string, ttl time.Duration) error {
+	ms.mx.Lock()
+	defer ms.mx.Unlock()
+	ms.data[key] = data
+	return nil
+}
+
+func (ms *mapCallbackStore) Load(key string) (string, bool, error) {
+	ms.mx.Lock()
+	defer ms.mx.Unlock()
+	data, ok := ms.data[key]
+	return data, ok, nil
+}
+
+func TestTypedCallbackDataStore(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	store := &mapCallbackStore{data: make(map[string]string)}
+	useStore := func(cfg *configs.BotConfigs) {
+		cfg.CallbackDataStore = store
+	}
+	long := strings.Repeat("a long note ", 10)
+	//The buttons are sent by a bot which doesn't receive the updates.
+	cfg := srv.Configs()
+	useStore(cfg)
+	sender, err := telego.NewBot(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb := sender.CreateInlineKeyboard()
+	if err := kb.AddTypedCallbackButton("long", testItem{Id: 7, Action: "edit", Note: long}, 1); err != nil {
+		t.Fatal(err)
+	}
+	if len(store.data) != 1 {
+		t.Fatal("the value was not kept in the store of the configs")
+	}
+	if _, err := sender.AdvancedMode().ASendMessage(10, "items", "", 0, 0, false, false, nil, nil, false, kb); err != nil {
+		t.Fatal(err)
+	}
+	markup := &objs.InlineKeyboardMarkup{}
+	if err := srv.ExpectCall(t, "sendMessage", nil).Decode("reply_markup", markup); err != nil {
+		t.Fatal(err)
+	}
+	data := markup.InlineKeyboard[0][0].CallbackData
+
+	//Another bot which uses the same store can decode the button, like a bot which has been restarted.
+	bot := startBot(t, srv, useStore)
+	telego.AddTypedCallbackHandler(bot, func(u *objs.Update, item testItem) {
+		bot.AnswerCallbackQuery(u.CallbackQuery.Id, item.Note, false)
+	})
+	srv.ExpectCallbackAnswer(t, srv.SendCallback(10, 10, 1, data), long)
+}
@@ -0,0 +1,83 @@
+package telego
+
+import (
+	objs "github.com/SakoDroid/telego/v2/objects"
+)
+
+/*
+The methods in this file add handlers for the update types other than text messages and callback queries. Like the text handlers, these handlers are executed after the middlewares and
+updates of blocked users are not passed to them. Updates which are handled by a handler are not passed to the update channels.
+
+The updates which belong to a chat can be filtered by chat type. "chatTypes" can contain "private","group","supergroup","channel" or "all". If no chat type is given, the handler acts on all chats.
+The handlers of the edited messages and the channel posts also have a regex pattern which is matched against the text (or caption) of the message, like the text handlers.
+All the methods return an error if a chat type or the pattern is not valid.
+*/
+
+/*OnEditedMessage adds a handler for the edited messages which their text matches the given regex pattern.*/
+func (bot *Bot) OnEditedMessage(pattern string, handler func(*objs.Update), chatTypes ...string) error {
+	return bot.addMessageHandler("edited_message", pattern, handler, chatTypes)
+}
+
+/*OnChannelPost adds a handler for the new posts of the channels which their text matches the given regex pattern.*/
+func (bot *Bot) OnChannelPost(pattern string, handler func(*objs.Update)) error {
+	return bot.addMessageHandler("channel_post", pattern, handler, nil)
+}
+
+/*OnEditedChannelPost adds a handler for the edited posts of the channels which their text matches the given regex pattern.*/
+func (bot *Bot) OnEditedChannelPost(pattern string, handler func(*objs.Update)) error {
+	return bot.addMessageHandler("edited_channel_post", pattern, handler, nil)
+}
+
+/*OnInlineQuery adds a handler for the inline queries. Use "AnswerInlineQuery" method to answer the queries.*/
+func (bot *Bot) OnInlineQuery(handler func(*objs.Update)) error {
+	return bot.addUpdateHandler("inline_query", handler, nil)
+}
+
+/*OnChosenInlineResult adds a handler for the inline results which have been chosen by the users. Inline feedback should be enabled in botfather to receive these updates.*/
+func (bot *Bot) OnChosenInlineResult(handler func(*objs.Update)) error {
+	return bot.addUpdateHandler("chosen_inline_result", handler, nil)
+}
+
+/*OnShippingQuery adds a handler for the shipping queries. Only invoices with flexible price receive these updates.*/
+func (bot *Bot) OnShippingQuery(handler func(*objs.Update)) error {
+	return bot.addUpdateHandler("shipping_query", handler, nil)
+}
+
+/*OnPreCheckoutQuery adds a handler for the pre checkout queries. The queries should be answered within 10 seconds using "AnswerPreCheckoutQuery" method.*/
+func (bot *Bot) OnPreCheckoutQuery(handler func(*objs.Update)) error {
+	return bot.addUpdateHandler("pre_checkout_query", handler, nil)
+}
+
+/*OnPollAnswer adds a handler for the answers of non anonymous polls.*/
+func (bot *Bot) OnPollAnswer(handler func(*objs.Update)) error {
+	return bot.addUpdateHandler("poll_answer", handler, nil)
+}
+
+/*OnMyChatMember adds a handler for the changes of the bot's own membership status in the chats.*/
+func (bot *Bot) OnMyChatMember(handler func(*objs.Update), chatTypes ...string) error {
+	return bot.addUpdateHandler("my_chat_member", handler, chatTypes)
+}
+
+/*OnChatMember adds a handler for the changes of the members of the chats. "chat_member" should be in the allowed updates of the update configs to receive these updates and the bot must be an administrator.*/
+func (bot *Bot) OnChatMember(handler func(*objs.Update), chatTypes ...string) error {
+	return bot.addUpdateHandler("chat_member", handler, chatTypes)
+}
+
+/*OnChatJoinRequest adds a handler for the requests to join the chats. The bot must have can_invite_users right in the chat to receive these updates.*/
+func (bot *Bot) OnChatJoinRequest(handler func(*objs.Update), chatTypes ...string) error {
+	return bot.addUpdateHandler("chat_join_request", handler, chatTypes)
+}
+
+func (bot *Bot) addMessageHandler(updateType, pattern string, handler func(*objs.Update), chatTypes []string) error {
+	if err := checkChatTypes(chatTypes); err != nil {
+		return err
+	}
+	return bot.apiInterface.GetUpdateParser().AddMessageHandler(updateType, pattern, handler, chatTypes...)
+}
+
+func (bot *Bot) addUpdateHandler(updateType string, handler func(*objs.Update), chatTypes []string) error {
+	if err := checkChatTypes(chatTypes); err != nil {
+		return err
+	}
+	return bot.apiInterface.GetUpdateParser().AddUpdateHandler(updateType, handler, chatTypes...)
+}
@@ -0,0 +1,100 @@
+package telego_test
+
+import (
+	"encoding/json"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	telego "github.com/SakoDroid/telego/v2"
+	"github.com/SakoDroid/telego/v2/configs"
+	objs "github.com/SakoDroid/telego/v2/objects"
+	"github.com/SakoDroid/telego/v2/telegotest"
+)
+
+/*Starts a bot which receives the updates through it's webhook handler and replies in the webhook responses.*/
+func startReplyingWebhookBot(t *testing.T, srv *telegotest.Server) *telego.Bot {
+	t.Helper()
+	return startBot(t, srv, func(cfg *configs.BotConfigs) {
+		cfg.Webhook = true
+		cfg.WebHookConfigs = &configs.WebHookConfigs{URL: "https://example.com/hook", ExternalServer: true, ReplyInResponse: true, ReplyTimeout: 5 * time.Second}
+	})
+}
+
+/*Posts the given update to the webhook handler of the bot and returns the body of the response.*/
+func postUpdate(t *testing.T, bot *telego.Bot, update *objs.Update) string {
+	t.Helper()
+	bt, err := json.Marshal(update)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest("POST", "/hook", strings.NewReader(string(bt)))
+	req.Header.Set("Content-Type", "application/json")
+	rec := httptest.NewRecorder()
+	bot.WebhookHandler().ServeHTTP(rec, req)
+	if rec.Code != 200 {
+		t.Fatalf("webhook responded with %d", rec.Code)
+	}
+	return rec.Body.String()
+}
+
+func TestPollSendInWebhookReplyMode(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startReplyingWebhookBot(t, srv)
+	sent := make(chan *telego.Poll, 1)
+	bot.AddHandler("poll", func(u *objs.Update) {
+		poll, err := bot.CreatePoll(u.Message.Chat.Id, "Which one ?", "regular")
+		if err != nil {
+			t.Error(err)
+		}
+		poll.AddOption("first")
+		poll.AddOption("second")
+		if err := poll.Send(false, false, 0); err != nil {
+			t.Error(err)
+		}
+		sent <- poll
+	}, "all")
+	body := postUpdate(t, bot, &objs.Update{Update_id: 1, Message: &objs.Message{MessageId: 1, Chat: &objs.Chat{Id: 10, Type: "private"}, Text: "poll"}})
+	if body != "" {
+		t.Fatalf("sendPoll has been sent in the webhook response : %s", body)
+	}
+	if poll := <-sent; poll.GetId() == "" || bot.GetPoll(poll.GetId()) != poll {
+		t.Fatal("the result of sendPoll is not available")
+	}
+	srv.ExpectCall(t, "sendPoll", nil)
+}
+
+func TestReplyInWebhook(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startReplyingWebhookBot(t, srv)
+	bot.AddHandler("hi", func(u *objs.Update) {
+		bot.ReplyInWebhook(u).SendMessage(u.Message.Chat.Id, "hello", "", 0, false, false, nil)
+	}, "all")
+	body := postUpdate(t, bot, &objs.Update{Update_id: 1, Message: &objs.Message{MessageId: 1, Chat: &objs.Chat{Id: 10, Type: "private"}, Text: "hi"}})
+	reply := make(map[string]any)
+	if err := json.Unmarshal([]byte(body), &reply); err != nil || reply["method"] != "sendMessage" || reply["text"] != "hello" {
+		t.Fatalf("unexpected webhook response : %q", body)
+	}
+	if len(srv.Calls("sendMessage")) != 0 {
+		t.Error("the replied message has been sent to the api server too")
+	}
+}
+
+func TestWebhookResponseAfterHandlers(t *testing.T) {
+	srv := telegotest.NewServer(t)
+	bot := startReplyingWebhookBot(t, srv)
+	bot.AddHandler("hi", func(u *objs.Update) {
+		//Calls sent without ReplyInWebhook are never written in the response.
+		bot.SendMessage(u.Message.Chat.Id, "hello", "", 0, false, false, nil)
+	}, "all")
+	start := time.Now()
+	body := postUpdate(t, bot, &objs.Update{Update_id: 1, Message: &objs.Message{MessageId: 1, Chat: &objs.Chat{Id: 10, Type: "private"}, Text: "hi"}})
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Fatalf("the response was written after %v", elapsed)
+	}
+	if body != "" {
+		t.Fatalf("unexpected webhook response : %q", body)
+	}
+	srv.ExpectCall(t, "sendMessage", nil)
+}